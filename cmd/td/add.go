@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+	"github.com/voioo/td/internal/ui"
+)
+
+// runAdd adds a task written like in the add mode of the interface, e.g.
+// td add deploy !high +website due:fri. The addition can be undone in the
+// interface.
+func runAdd(cfg *config.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("add", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: td add NAME [!high] [#tag] [+project] [due:DATE] [every:RULE]")
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	details, err := ui.ParseQuickAdd(strings.Join(flags.Args(), " "), time.Now())
+	if err != nil {
+		return err
+	}
+
+	repo := storage.NewRepository(cfg.DataFile)
+	activeTasks, doneTasks, nextID, err := repo.LoadTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+	taskManager := task.NewTaskManager(activeTasks, doneTasks, nextID)
+	undoManager := task.NewUndoManager(cfg.UndoLimit)
+	if history, err := repo.LoadHistory(); err == nil {
+		undoManager.Import(history, taskManager)
+	}
+
+	added := taskManager.AddTaskWith(details)
+	undoManager.PushUndo(&task.AddCommand{Task: added})
+	if err := repo.SaveTasks(taskManager.GetTasks(), taskManager.GetDoneTasks()); err != nil {
+		return fmt.Errorf("failed to save tasks: %w", err)
+	}
+	if err := repo.SaveHistory(undoManager.Export()); err != nil {
+		return fmt.Errorf("failed to save undo history: %w", err)
+	}
+
+	fmt.Fprintln(out, "Added", formatListTask(added))
+	return nil
+}
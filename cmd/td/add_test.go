@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestRunAdd(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataFile = filepath.Join(t.TempDir(), "tasks.json")
+
+	var out bytes.Buffer
+	if err := runAdd(cfg, []string{"deploy", "!high", "+web", "#ops", "due:2026-11-03"}, &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := "Added #1 [ ] deploy !high +web #ops due 2026-11-03\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+
+	repo := storage.NewRepository(cfg.DataFile)
+	tasks, _, _, err := repo.LoadTasks()
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("expected the task to be saved, got %d tasks (%v)", len(tasks), err)
+	}
+	history, err := repo.LoadHistory()
+	if err != nil || len(history.Undo) != 1 {
+		t.Errorf("expected the addition in the undo history, got %+v (%v)", history, err)
+	}
+
+	if err := runAdd(cfg, []string{"!high"}, &out); err == nil {
+		t.Error("expected an error for a task without a name")
+	}
+	if err := runAdd(cfg, []string{strings.Repeat("x", task.MaxNameLength+1)}, &out); err == nil {
+		t.Error("expected an error for a name that is too long")
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/query"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+)
+
+// runList prints the tasks matching a query, active tasks first, each in the
+// sort order of the config: td list [--where QUERY].
+func runList(cfg *config.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	flags.SetOutput(out)
+	where := flags.String("where", "not done", "query selecting the tasks to list, e.g. \"prio>=medium and tag:ops\"")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q, quote the query of --where", flags.Arg(0))
+	}
+	q, err := query.Parse(*where)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	repo := storage.NewRepository(cfg.DataFile)
+	activeTasks, doneTasks, nextID, err := repo.LoadTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+	taskManager := task.NewTaskManager(activeTasks, doneTasks, nextID)
+	if sortMode, err := task.ParseSortMode(cfg.SortMode); err == nil {
+		taskManager.SetSortMode(sortMode)
+	}
+	taskManager.SetSortReverse(cfg.SortReverse)
+
+	env := query.Env{Tasks: taskManager, Now: time.Now()}
+	tasks := append(taskManager.GetTasks(), taskManager.GetDoneTasks()...)
+	for _, t := range q.Filter(tasks, env) {
+		fmt.Fprintln(out, formatListTask(t))
+	}
+	return nil
+}
+
+// formatListTask formats a task as a single line such as
+// "#3 [ ] deploy !high +website #ops due 2026-11-03".
+func formatListTask(t *task.Task) string {
+	box := "[ ]"
+	switch t.CurrentStatus() {
+	case task.StatusDone:
+		box = "[x]"
+	case task.StatusCancelled:
+		box = "[-]"
+	case task.StatusInProgress, task.StatusWaiting, task.StatusBlocked:
+		box = "[~]"
+	}
+	parts := []string{fmt.Sprintf("#%d", t.ID), box, t.Name}
+	if box == "[~]" {
+		parts = append(parts, "("+strings.ToLower(t.CurrentStatus().Label())+")")
+	}
+	if t.Priority != task.PriorityNone {
+		parts = append(parts, "!"+t.Priority.String())
+	}
+	if t.Project != "" {
+		parts = append(parts, task.FormatProject(t.Project))
+	}
+	if len(t.Tags) > 0 {
+		parts = append(parts, task.FormatTags(t.Tags))
+	}
+	if t.DueAt != nil {
+		parts = append(parts, "due "+t.DueAt.Format("2006-01-02"))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestRunList(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataFile = filepath.Join(t.TempDir(), "tasks.json")
+
+	tm := task.NewTaskManager(nil, nil, 0)
+	deploy := tm.AddTask("deploy")
+	tm.SetTaskPriority(deploy.ID, task.PriorityHigh)
+	tm.SetTaskTags(deploy.ID, []string{"ops"})
+	tm.SetTaskProject(deploy.ID, "api")
+	docs := tm.AddTask("docs")
+	if _, err := tm.SetTaskStatus(docs.ID, task.StatusWaiting); err != nil {
+		t.Fatal(err)
+	}
+	done := tm.AddTask("release")
+	tm.CompleteTask(done.ID)
+	dropped := tm.AddTask("rewrite")
+	if _, err := tm.SetTaskStatus(dropped.ID, task.StatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.NewRepository(cfg.DataFile).SaveTasks(tm.GetTasks(), tm.GetDoneTasks()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{nil, "#1 [ ] deploy !high +api #ops\n#2 [~] docs (waiting)\n"},
+		{[]string{"--where", "prio>=medium and tag:ops"}, "#1 [ ] deploy !high +api #ops\n"},
+		{[]string{"--where", "status:done"}, "#3 [x] release\n"},
+		{[]string{"--where", "status:cancelled"}, "#4 [-] rewrite\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := runList(cfg, tt.args, &out); err != nil {
+			t.Fatalf("expected no error for %v, got %v", tt.args, err)
+		}
+		if out.String() != tt.expected {
+			t.Errorf("expected %q for %v, got %q", tt.expected, tt.args, out.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runList(cfg, []string{"--where", "prio>=urgent"}, &out); err == nil || !strings.Contains(err.Error(), "invalid query") {
+		t.Errorf("expected an invalid query error, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -41,9 +42,32 @@ func initializeModel(cfg *config.Config) (tea.Model, error) {
 
 	// Create task manager
 	taskManager := task.NewTaskManager(activeTasks, doneTasks, nextID)
+	sortMode, err := task.ParseSortMode(cfg.SortMode)
+	if err != nil {
+		logger.Warn("Invalid sort mode, using priority order", logger.F("error", err))
+		sortMode = task.SortPriority
+	}
+	taskManager.SetSortMode(sortMode)
+	taskManager.SetSortReverse(cfg.SortReverse)
+	transitions, err := task.ParseTransitions(cfg.StatusTransitions)
+	if err != nil {
+		logger.Warn("Invalid status transitions, allowing all", logger.F("error", err))
+	}
+	taskManager.SetTransitions(transitions)
+
+	// Restore the undo history of the previous session
+	undoManager := task.NewUndoManager(cfg.UndoLimit)
+	history, err := repo.LoadHistory()
+	if err != nil {
+		logger.Warn("Discarding undo history", logger.F("error", err))
+	} else {
+		undoManager.Import(history, taskManager)
+	}
 
 	// Create UI model
 	uiModel := ui.NewModel(cfg, taskManager)
+	uiModel.SetUndoManager(undoManager)
+	uiModel.SetConfigPath(config.GetConfigPath())
 
 	logger.Info("Application initialized successfully")
 	return uiModel, nil
@@ -86,11 +110,26 @@ func main() {
 	upgrade.CleanupOldExecutables()
 
 	// Load configuration
-	cfg, err := config.LoadConfig("")
+	cfg, err := config.LoadConfig(config.GetConfigPath())
 	if err != nil {
 		logger.Fatal("Failed to load configuration", logger.F("error", err))
 	}
 
+	// Commands that print and exit instead of starting the interface
+	commands := map[string]func(*config.Config, []string, io.Writer) error{
+		"list":   runList,
+		"add":    runAdd,
+		"stats":  runStats,
+		"report": runReport,
+	}
+	if run, ok := commands[flag.Arg(0)]; ok {
+		if err := run(cfg, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Initialize the model
 	model, err := initializeModel(cfg)
 	if err != nil {
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+)
+
+// runReport prints a report; the only one so far is the time report:
+// td report time [--since DAY] [--until DAY].
+func runReport(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "time" {
+		return errors.New("unknown report, use: td report time [--since DAY] [--until DAY]")
+	}
+	return runTimeReport(cfg, args[1:], out, time.Now())
+}
+
+// reportLine is a row of the time report.
+type reportLine struct {
+	name    string
+	tracked time.Duration
+}
+
+// runTimeReport prints the time tracked between two days by task and by tag.
+// Tasks with several tags count towards each of them.
+func runTimeReport(cfg *config.Config, args []string, out io.Writer, now time.Time) error {
+	flags := flag.NewFlagSet("report time", flag.ContinueOnError)
+	flags.SetOutput(out)
+	since := flags.String("since", "monday", "first day of the report: today, yesterday, a weekday, 2006-01-02, 3d or 2w ago")
+	until := flags.String("until", "today", "last day of the report, written like --since")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	from, err := parseReportDay(*since, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	last, err := parseReportDay(*until, now)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+	to := last.AddDate(0, 0, 1)
+	if !to.After(from) {
+		return errors.New("--until cannot be before --since")
+	}
+
+	repo := storage.NewRepository(cfg.DataFile)
+	activeTasks, doneTasks, _, err := repo.LoadTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	var byTask []reportLine
+	tagTime := make(map[string]time.Duration)
+	var total time.Duration
+	for _, t := range append(activeTasks, doneTasks...) {
+		tracked := t.TrackedBetween(from, to, now)
+		if tracked <= 0 {
+			continue
+		}
+		total += tracked
+		byTask = append(byTask, reportLine{fmt.Sprintf("#%d %s", t.ID, t.Name), tracked})
+		if len(t.Tags) == 0 {
+			tagTime["untagged"] += tracked
+		}
+		for _, tag := range t.Tags {
+			tagTime["#"+tag] += tracked
+		}
+	}
+	var byTag []reportLine
+	for tag, tracked := range tagTime {
+		byTag = append(byTag, reportLine{tag, tracked})
+	}
+
+	period := from.Format("Mon 2006-01-02")
+	if last.After(from) {
+		period += " to " + last.Format("Mon 2006-01-02")
+	}
+	fmt.Fprintf(out, "Time tracked %s\n", period)
+	if total == 0 {
+		fmt.Fprintln(out, "Nothing tracked")
+		return nil
+	}
+	// Names are padded by their width in the terminal, which differs from
+	// their length for wide characters
+	width := len("Total")
+	for _, line := range append(byTask, byTag...) {
+		width = max(width, lipgloss.Width(line.name)+2)
+	}
+	for _, section := range []struct {
+		title string
+		lines []reportLine
+	}{
+		{"By task", byTask},
+		{"By tag", byTag},
+	} {
+		sortReportLines(section.lines)
+		fmt.Fprintf(out, "\n%s\n", section.title)
+		for _, line := range section.lines {
+			fmt.Fprintf(out, "  %s  %7s\n", padRight(line.name, width-2), task.FormatDuration(line.tracked))
+		}
+	}
+	fmt.Fprintf(out, "\n%s  %7s\n", padRight("Total", width), task.FormatDuration(total))
+	return nil
+}
+
+// padRight pads s with spaces to the given width in terminal columns.
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// sortReportLines sorts report lines by tracked time, longest first, then by
+// name.
+func sortReportLines(lines []reportLine) {
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].tracked != lines[j].tracked {
+			return lines[i].tracked > lines[j].tracked
+		}
+		return lines[i].name < lines[j].name
+	})
+}
+
+// parseReportDay parses a day up to today: today, yesterday, the last day of
+// the week with the given name (today included), a date such as 2006-01-02,
+// or a number of days or weeks ago such as 3d or 2w.
+func parseReportDay(value string, now time.Time) (time.Time, error) {
+	today := task.StartOfDay(now)
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		weekday := strings.ToLower(day.String())
+		if value == weekday || value == weekday[:3] {
+			days := (int(today.Weekday()) - int(day) + 7) % 7
+			return today.AddDate(0, 0, -days), nil
+		}
+	}
+
+	if len(value) > 1 {
+		if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n >= 0 {
+			switch value[len(value)-1] {
+			case 'd':
+				return today.AddDate(0, 0, -n), nil
+			case 'w':
+				return today.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day %q, use today, yesterday, a weekday, 2006-01-02, 3d or 2w", value)
+	}
+	return date, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestRunTimeReport(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataFile = filepath.Join(t.TempDir(), "tasks.json")
+	now := time.Date(2026, 10, 21, 12, 0, 0, 0, time.Local) // a Wednesday
+	at := func(days, hour int) time.Time {
+		return task.StartOfDay(now).AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+	}
+	entry := func(days, hour int, length time.Duration) task.TimeEntry {
+		end := at(days, hour).Add(length)
+		return task.TimeEntry{Start: at(days, hour), End: &end}
+	}
+
+	tm := task.NewTaskManager(nil, nil, 0)
+	deploy := tm.AddTask("deploy")
+	tm.SetTaskTags(deploy.ID, []string{"ops", "client"})
+	deploy.TimeEntries = []task.TimeEntry{entry(-1, 9, 2*time.Hour), entry(-7, 9, time.Hour)}
+	docs := tm.AddTask("docs")
+	docs.TimeEntries = []task.TimeEntry{{Start: at(0, 11)}}
+	if err := storage.NewRepository(cfg.DataFile).SaveTasks(tm.GetTasks(), tm.GetDoneTasks()); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runTimeReport(cfg, []string{"--since", "monday"}, &out, now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := `Time tracked Mon 2026-10-19 to Wed 2026-10-21
+
+By task
+  #1 deploy       2h
+  #2 docs         1h
+
+By tag
+  #client         2h
+  #ops            2h
+  untagged        1h
+
+Total             3h
+`
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	out.Reset()
+	if err := runTimeReport(cfg, []string{"--since", "2026-10-01", "--until", "2026-10-14"}, &out, now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "#1 deploy       1h") {
+		t.Errorf("expected the hour of the previous week, got:\n%s", out.String())
+	}
+
+	if err := runTimeReport(cfg, []string{"--since", "someday"}, &out, now); err == nil {
+		t.Error("expected an error for an invalid day")
+	}
+	if err := runReport(cfg, []string{"money"}, &out); err == nil {
+		t.Error("expected an error for an unknown report")
+	}
+}
+
+func TestRunTimeReportWideNames(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataFile = filepath.Join(t.TempDir(), "tasks.json")
+	now := time.Date(2026, 10, 21, 12, 0, 0, 0, time.Local)
+	start := now.Add(-3 * time.Hour)
+	end := now.Add(-time.Hour)
+
+	tm := task.NewTaskManager(nil, nil, 0)
+	for _, name := range []string{"週次会議", "café", "deploy"} {
+		added := tm.AddTask(name)
+		added.TimeEntries = []task.TimeEntry{{Start: start, End: &end}}
+	}
+	if err := storage.NewRepository(cfg.DataFile).SaveTasks(tm.GetTasks(), tm.GetDoneTasks()); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runTimeReport(cfg, nil, &out, now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// Every line with a duration ends in the same terminal column
+	var widths []int
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.HasSuffix(line, "h") {
+			widths = append(widths, lipgloss.Width(line))
+		}
+	}
+	if len(widths) != 5 || slices.Min(widths) != slices.Max(widths) {
+		t.Errorf("expected the durations to line up, got widths %v in:\n%s", widths, out.String())
+	}
+}
+
+func TestParseReportDay(t *testing.T) {
+	now := time.Date(2026, 10, 21, 12, 0, 0, 0, time.Local) // a Wednesday
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"today", "2026-10-21"},
+		{"yesterday", "2026-10-20"},
+		{"monday", "2026-10-19"},
+		{"Wed", "2026-10-21"},
+		{"thu", "2026-10-15"},
+		{"3d", "2026-10-18"},
+		{"2w", "2026-10-07"},
+		{"2026-09-30", "2026-09-30"},
+	}
+	for _, tt := range tests {
+		day, err := parseReportDay(tt.value, now)
+		if err != nil || day.Format("2006-01-02") != tt.expected {
+			t.Errorf("parseReportDay(%q) = %v (%v), want %s", tt.value, day, err, tt.expected)
+		}
+	}
+}
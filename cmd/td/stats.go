@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+	"github.com/voioo/td/internal/ui"
+)
+
+// runStats prints statistics of all tasks, open and closed, as charts or as
+// JSON: td stats [--days N] [--weeks N] [--json].
+func runStats(cfg *config.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(out)
+	days := flags.Int("days", ui.StatsDays, "number of days counted day by day")
+	weeks := flags.Int("weeks", ui.StatsWeeks, "number of weeks counted week by week")
+	asJSON := flags.Bool("json", false, "print the statistics as JSON")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	if *days < 1 || *weeks < 1 {
+		return errors.New("--days and --weeks must be at least 1")
+	}
+
+	repo := storage.NewRepository(cfg.DataFile)
+	activeTasks, doneTasks, _, err := repo.LoadTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+	stats := task.ComputeStats(append(activeTasks, doneTasks...), time.Now(), *days, *weeks)
+
+	if *asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+	accent := lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.PrimaryColor))
+	fmt.Fprint(out, ui.RenderStats(stats, accent))
+	return nil
+}
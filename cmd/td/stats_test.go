@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/storage"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestRunStats(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataFile = filepath.Join(t.TempDir(), "tasks.json")
+
+	tm := task.NewTaskManager(nil, nil, 0)
+	tm.AddTask("deploy")
+	done := tm.AddTask("release")
+	tm.CompleteTask(done.ID)
+	dropped := tm.AddTask("rewrite")
+	if _, err := tm.SetTaskStatus(dropped.ID, task.StatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.NewRepository(cfg.DataFile).SaveTasks(tm.GetTasks(), tm.GetDoneTasks()); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runStats(cfg, nil, &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"1 open · 1 completed · 1 cancelled", "Streak: 1 day", "Last 14 days"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected the report to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runStats(cfg, []string{"--json", "--days", "3", "--weeks", "2"}, &out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var stats task.Stats
+	if err := json.Unmarshal(out.Bytes(), &stats); err != nil {
+		t.Fatalf("expected JSON, got %v:\n%s", err, out.String())
+	}
+	if len(stats.Days) != 3 || len(stats.Weeks) != 2 || stats.Days[2].Completed != 1 || stats.Days[2].Cancelled != 1 {
+		t.Errorf("expected 3 days and 2 weeks with today's completion and cancellation, got %+v", stats)
+	}
+
+	if err := runStats(cfg, []string{"--days", "0"}, &out); err == nil {
+		t.Error("expected an error for zero days")
+	}
+}
@@ -103,29 +103,20 @@ func TestFullApplicationFlow(t *testing.T) {
 
 		// Add a task
 		addedTask := tm.AddTask("Test undo/redo")
-		undoManager.PushUndo(task.Action{
-			Type: task.ActionTypeAdd,
-			Task: addedTask,
-		})
+		undoManager.PushUndo(&task.AddCommand{Task: addedTask})
 
 		// Change priority
 		oldPriority := addedTask.Priority
 		tm.SetTaskPriority(addedTask.ID, task.PriorityHigh)
-		undoManager.PushUndo(task.Action{
-			Type:     task.ActionTypePriority,
-			Task:     addedTask,
-			OldState: oldPriority,
-			NewState: task.PriorityHigh,
+		undoManager.PushUndo(&task.PriorityCommand{
+			TaskID: addedTask.ID,
+			Old:    oldPriority,
+			New:    task.PriorityHigh,
 		})
 
 		// Complete the task
 		completedTask := tm.CompleteTask(addedTask.ID)
-		undoManager.PushUndo(task.Action{
-			Type:     task.ActionTypeComplete,
-			Task:     completedTask,
-			OldState: false,
-			NewState: true,
-		})
+		undoManager.PushUndo(&task.CompleteCommand{TaskID: completedTask.ID})
 
 		// Verify initial state
 		if len(tm.GetTasks()) != 0 {
@@ -139,7 +130,7 @@ func TestFullApplicationFlow(t *testing.T) {
 		}
 
 		// Undo completion
-		if !undoManager.Undo(tm) {
+		if undoManager.Undo(tm) != nil {
 			t.Error("expected undo to succeed")
 		}
 
@@ -151,7 +142,7 @@ func TestFullApplicationFlow(t *testing.T) {
 		}
 
 		// Undo priority change
-		if !undoManager.Undo(tm) {
+		if undoManager.Undo(tm) != nil {
 			t.Error("expected undo priority change to succeed")
 		}
 
@@ -160,7 +151,7 @@ func TestFullApplicationFlow(t *testing.T) {
 		}
 
 		// Undo add
-		if !undoManager.Undo(tm) {
+		if undoManager.Undo(tm) != nil {
 			t.Error("expected undo add to succeed")
 		}
 
@@ -169,7 +160,7 @@ func TestFullApplicationFlow(t *testing.T) {
 		}
 
 		// Redo operations
-		if !undoManager.Redo(tm) {
+		if undoManager.Redo(tm) != nil {
 			t.Error("expected redo to succeed")
 		}
 
@@ -3,9 +3,12 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/voioo/td/internal/storage"
@@ -20,45 +23,102 @@ const (
 	DefaultLowPriorityColor    = "#00FF00"
 )
 
+// DefaultStatusColors are the colors of the task statuses, by status name.
+var DefaultStatusColors = map[string]string{
+	"in_progress": "#2196F3",
+	"waiting":     "#9C27B0",
+	"blocked":     "#FF9800",
+	"done":        "#4CAF50",
+	"cancelled":   "#9E9E9E",
+}
+
+// DefaultUndoLimit is the default number of undo steps kept, also across sessions.
+const DefaultUndoLimit = 100
+
+// DefaultSortMode is the default order of the task list.
+const DefaultSortMode = "priority"
+
+// DefaultGroupBy is the default grouping of the task list.
+const DefaultGroupBy = "none"
+
 // Config holds all configuration options for the td application.
 type Config struct {
 	// DataFile is the path to the data file.
-	DataFile string `json:"data_file"`
+	DataFile string `json:"data_file" yaml:"data_file"`
 	// Theme controls the UI appearance.
-	Theme Theme `json:"theme"`
+	Theme Theme `json:"theme" yaml:"theme"`
 	// KeyMap defines keyboard shortcuts.
-	KeyMap KeyMap `json:"keymap"`
+	KeyMap KeyMap `json:"keymap" yaml:"keymap"`
+	// UndoLimit is the number of undo steps kept in memory and saved with the data.
+	UndoLimit int `json:"undo_limit" yaml:"undo_limit"`
+	// SortMode is the order tasks are listed in: priority, manual, created, due or name.
+	SortMode string `json:"sort_mode" yaml:"sort_mode"`
+	// SortReverse lists tasks in the reverse of the sort mode.
+	SortReverse bool `json:"sort_reverse" yaml:"sort_reverse"`
+	// GroupBy groups the task list under headers: none, priority, project, tag or due.
+	GroupBy string `json:"group_by" yaml:"group_by"`
+	// Views are named filters of the task list with their own order.
+	Views []View `json:"views,omitempty" yaml:"views,omitempty"`
+	// SkipConfirm lists the actions done without asking first: delete, bulk
+	// (changes to several selected tasks) and clear_completed.
+	SkipConfirm []string `json:"skip_confirm,omitempty" yaml:"skip_confirm,omitempty"`
+	// StatusTransitions lists the statuses a task may move to from each
+	// status, e.g. {"todo": ["in_progress", "cancelled"]}. Statuses that are
+	// not listed may move to any status.
+	StatusTransitions map[string][]string `json:"status_transitions,omitempty" yaml:"status_transitions,omitempty"`
+}
+
+// SkipsConfirm reports whether an action is done without asking first.
+func (c *Config) SkipsConfirm(action string) bool {
+	return slices.Contains(c.SkipConfirm, action)
+}
+
+// View is a named filter of the task list, optionally with its own sort
+// order and grouping.
+type View struct {
+	// Name is shown in the title while the view is active.
+	Name string `json:"name" yaml:"name"`
+	// Filter selects the tasks shown, e.g. "due<=today and not blocked".
+	Filter string `json:"filter" yaml:"filter"`
+	// Sort is the sort mode of the view, empty to keep the current one.
+	Sort string `json:"sort,omitempty" yaml:"sort,omitempty"`
+	// Reverse lists the tasks in the reverse of the sort mode.
+	Reverse bool `json:"reverse,omitempty" yaml:"reverse,omitempty"`
+	// Group groups the view under headers, empty to keep the current grouping.
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
 }
 
 // Theme defines the visual appearance settings.
 type Theme struct {
 	// PrimaryColor is the main accent color.
-	PrimaryColor string `json:"primary_color"`
+	PrimaryColor string `json:"primary_color" yaml:"primary_color"`
 	// HighPriorityColor for high priority tasks.
-	HighPriorityColor string `json:"high_priority_color"`
+	HighPriorityColor string `json:"high_priority_color" yaml:"high_priority_color"`
 	// MediumPriorityColor for medium priority tasks.
-	MediumPriorityColor string `json:"medium_priority_color"`
+	MediumPriorityColor string `json:"medium_priority_color" yaml:"medium_priority_color"`
 	// LowPriorityColor for low priority tasks.
-	LowPriorityColor string `json:"low_priority_color"`
+	LowPriorityColor string `json:"low_priority_color" yaml:"low_priority_color"`
+	// StatusColors for task statuses, by status name such as "in_progress".
+	StatusColors map[string]string `json:"status_colors,omitempty" yaml:"status_colors,omitempty"`
 }
 
 // KeyMap defines keyboard shortcuts.
 type KeyMap struct {
-	Add      string `json:"add"`
-	Delete   string `json:"delete"`
-	Enter    string `json:"enter"`
-	Escape   string `json:"escape"`
-	Up       string `json:"up"`
-	Down     string `json:"down"`
-	Left     string `json:"left"`
-	Right    string `json:"right"`
-	ListType string `json:"list_type"`
-	Help     string `json:"help"`
-	Quit     string `json:"quit"`
-	Priority string `json:"priority"`
-	Filter   string `json:"filter"`
-	Undo     string `json:"undo"`
-	Redo     string `json:"redo"`
+	Add      string `json:"add" yaml:"add"`
+	Delete   string `json:"delete" yaml:"delete"`
+	Enter    string `json:"enter" yaml:"enter"`
+	Escape   string `json:"escape" yaml:"escape"`
+	Up       string `json:"up" yaml:"up"`
+	Down     string `json:"down" yaml:"down"`
+	Left     string `json:"left" yaml:"left"`
+	Right    string `json:"right" yaml:"right"`
+	ListType string `json:"list_type" yaml:"list_type"`
+	Help     string `json:"help" yaml:"help"`
+	Quit     string `json:"quit" yaml:"quit"`
+	Priority string `json:"priority" yaml:"priority"`
+	Filter   string `json:"filter" yaml:"filter"`
+	Undo     string `json:"undo" yaml:"undo"`
+	Redo     string `json:"redo" yaml:"redo"`
 }
 
 // DefaultConfig returns a configuration with default values.
@@ -70,6 +130,7 @@ func DefaultConfig() *Config {
 			HighPriorityColor:   DefaultHighPriorityColor,
 			MediumPriorityColor: DefaultMediumPriorityColor,
 			LowPriorityColor:    DefaultLowPriorityColor,
+			StatusColors:        maps.Clone(DefaultStatusColors),
 		},
 		KeyMap: KeyMap{
 			Add:      "a",
@@ -88,6 +149,9 @@ func DefaultConfig() *Config {
 			Undo:     "ctrl+u",
 			Redo:     "ctrl+r",
 		},
+		UndoLimit: DefaultUndoLimit,
+		SortMode:  DefaultSortMode,
+		GroupBy:   DefaultGroupBy,
 	}
 }
 
@@ -151,6 +215,15 @@ func mergeWithDefaults(config, defaults *Config) {
 	if config.DataFile == "" {
 		config.DataFile = defaults.DataFile
 	}
+	if config.UndoLimit <= 0 {
+		config.UndoLimit = defaults.UndoLimit
+	}
+	if config.SortMode == "" {
+		config.SortMode = defaults.SortMode
+	}
+	if config.GroupBy == "" {
+		config.GroupBy = defaults.GroupBy
+	}
 	if config.Theme.PrimaryColor == "" {
 		config.Theme.PrimaryColor = defaults.Theme.PrimaryColor
 	}
@@ -163,6 +236,14 @@ func mergeWithDefaults(config, defaults *Config) {
 	if config.Theme.LowPriorityColor == "" {
 		config.Theme.LowPriorityColor = defaults.Theme.LowPriorityColor
 	}
+	if config.Theme.StatusColors == nil {
+		config.Theme.StatusColors = make(map[string]string)
+	}
+	for status, color := range defaults.Theme.StatusColors {
+		if config.Theme.StatusColors[status] == "" {
+			config.Theme.StatusColors[status] = color
+		}
+	}
 
 	// Fill in missing keymap entries
 	if config.KeyMap.Add == "" {
@@ -249,11 +330,158 @@ func (c *Config) SaveConfig(configPath string) error {
 	return nil
 }
 
-// GetConfigPath returns the default configuration file path.
+// savedSettings are the keys of the settings td changes while it runs, in the
+// order they are added to a configuration file that does not have them yet.
+var savedSettings = []string{"sort_mode", "sort_reverse", "group_by", "skip_confirm"}
+
+// settings returns the values of savedSettings, without the ones that are
+// left out of the file when empty.
+func (c *Config) settings() map[string]any {
+	settings := map[string]any{
+		"sort_mode":    c.SortMode,
+		"sort_reverse": c.SortReverse,
+		"group_by":     c.GroupBy,
+	}
+	if len(c.SkipConfirm) > 0 {
+		settings["skip_confirm"] = c.SkipConfirm
+	}
+	return settings
+}
+
+// SaveSettings writes the settings td changes while it runs (the sort order,
+// the grouping and the skipped confirmations) to the configuration file,
+// leaving the rest of the file as it is. The file is created when it does not
+// exist yet. Supports both JSON and YAML formats based on file extension.
+func (c *Config) SaveSettings(configPath string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if detectConfigFormat(configPath) == ConfigFormatYAML {
+		data, err = c.mergeYAMLSettings(data)
+	} else {
+		data, err = c.mergeJSONSettings(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// mergeJSONSettings sets the saved settings in a JSON configuration file.
+// The other values are kept as they are, the keys end up sorted.
+func (c *Config) mergeJSONSettings(data []byte) ([]byte, error) {
+	values := make(map[string]json.RawMessage)
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON config file: %w", err)
+		}
+	}
+
+	settings := c.settings()
+	for _, key := range savedSettings {
+		value, ok := settings[key]
+		if !ok {
+			delete(values, key)
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", key, err)
+		}
+		values[key] = raw
+	}
+
+	merged, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config to JSON: %w", err)
+	}
+	return append(merged, '\n'), nil
+}
+
+// mergeYAMLSettings sets the saved settings in a YAML configuration file,
+// keeping the order of its keys and its comments.
+func (c *Config) mergeYAMLSettings(data []byte) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML config file: %w", err)
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("failed to parse YAML config file: not a mapping")
+	}
+
+	settings := c.settings()
+	for _, key := range savedSettings {
+		// Mapping nodes hold keys and values in turn
+		index := -1
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == key {
+				index = i
+				break
+			}
+		}
+
+		value, ok := settings[key]
+		switch {
+		case !ok && index >= 0:
+			root.Content = slices.Delete(root.Content, index, index+2)
+		case ok:
+			var node yaml.Node
+			if err := node.Encode(value); err != nil {
+				return nil, fmt.Errorf("failed to marshal %s: %w", key, err)
+			}
+			if index >= 0 {
+				previous := root.Content[index+1]
+				node.HeadComment, node.LineComment, node.FootComment = previous.HeadComment, previous.LineComment, previous.FootComment
+				root.Content[index+1] = &node
+			} else {
+				root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &node)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("failed to marshal config to YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config to YAML: %w", err)
+	}
+	return []byte(sb.String()), nil
+}
+
+// configFileNames are the names of the configuration file in the order they
+// are looked for.
+var configFileNames = []string{"config.json", "config.yaml", "config.yml"}
+
+// GetConfigPath returns the path of the configuration file: the first of
+// config.json, config.yaml and config.yml that exists in ~/.config/td, or
+// config.json when there is none yet.
 func GetConfigPath() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return ".td-config.json"
 	}
-	return filepath.Join(homeDir, ".config", "td", "config.json")
+	dir := filepath.Join(homeDir, ".config", "td")
+	for _, name := range configFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, configFileNames[0])
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -18,6 +19,22 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.KeyMap.Add != "a" {
 		t.Errorf("expected add key to be 'a', got %s", cfg.KeyMap.Add)
 	}
+	if cfg.UndoLimit != DefaultUndoLimit {
+		t.Errorf("expected undo limit to be %d, got %d", DefaultUndoLimit, cfg.UndoLimit)
+	}
+	if cfg.SortMode != DefaultSortMode {
+		t.Errorf("expected sort mode to be %s, got %s", DefaultSortMode, cfg.SortMode)
+	}
+	if cfg.GroupBy != DefaultGroupBy {
+		t.Errorf("expected group by to be %s, got %s", DefaultGroupBy, cfg.GroupBy)
+	}
+	if cfg.SkipsConfirm("delete") {
+		t.Error("expected deletes to be confirmed by default")
+	}
+	cfg.SkipConfirm = []string{"delete"}
+	if !cfg.SkipsConfirm("delete") || cfg.SkipsConfirm("bulk") {
+		t.Errorf("expected only deletes to skip confirmation, got %v", cfg.SkipConfirm)
+	}
 }
 
 func TestLoadConfig(t *testing.T) {
@@ -123,6 +140,25 @@ func TestLoadConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("load hand-written YAML file", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		handWritten := "data_file: /tmp/tasks.json\nundo_limit: 20\ntheme:\n  primary_color: \"#654321\"\nkeymap:\n  list_type: \"w\"\n"
+		if err := os.WriteFile(configFile, []byte(handWritten), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		loadedConfig, err := LoadConfig(configFile)
+		if err != nil {
+			t.Fatalf("expected no error loading YAML config, got %v", err)
+		}
+		if loadedConfig.DataFile != "/tmp/tasks.json" || loadedConfig.UndoLimit != 20 {
+			t.Errorf("expected the data file and undo limit from the file, got %q and %d", loadedConfig.DataFile, loadedConfig.UndoLimit)
+		}
+		if loadedConfig.Theme.PrimaryColor != "#654321" || loadedConfig.KeyMap.ListType != "w" {
+			t.Errorf("expected the theme and keymap from the file, got %+v", loadedConfig)
+		}
+	})
+
 	t.Run("merge with defaults for missing fields", func(t *testing.T) {
 		tempDir, err := os.MkdirTemp("", "td-config-test")
 		if err != nil {
@@ -133,7 +169,7 @@ func TestLoadConfig(t *testing.T) {
 		configFile := filepath.Join(tempDir, "config.json")
 
 		// Create partial config
-		partialConfig := `{"theme": {"primary_color": "#123456"}}`
+		partialConfig := `{"theme": {"primary_color": "#123456", "status_colors": {"blocked": "#000000"}}}`
 		err = os.WriteFile(configFile, []byte(partialConfig), 0644)
 		if err != nil {
 			t.Fatal(err)
@@ -149,6 +185,10 @@ func TestLoadConfig(t *testing.T) {
 			t.Errorf("expected primary color to be '#123456', got %s", loadedConfig.Theme.PrimaryColor)
 		}
 
+		if loadedConfig.Theme.StatusColors["blocked"] != "#000000" {
+			t.Errorf("expected custom blocked color, got %s", loadedConfig.Theme.StatusColors["blocked"])
+		}
+
 		// Should have default values for missing fields
 		if loadedConfig.Theme.HighPriorityColor != DefaultHighPriorityColor {
 			t.Errorf("expected default high priority color, got %s", loadedConfig.Theme.HighPriorityColor)
@@ -156,6 +196,12 @@ func TestLoadConfig(t *testing.T) {
 		if loadedConfig.KeyMap.Add != "a" {
 			t.Errorf("expected default add key, got %s", loadedConfig.KeyMap.Add)
 		}
+		if loadedConfig.UndoLimit != DefaultUndoLimit {
+			t.Errorf("expected default undo limit, got %d", loadedConfig.UndoLimit)
+		}
+		if loadedConfig.Theme.StatusColors["waiting"] != DefaultStatusColors["waiting"] {
+			t.Errorf("expected default status colors, got %v", loadedConfig.Theme.StatusColors)
+		}
 	})
 }
 
@@ -181,3 +227,122 @@ func TestSaveConfig(t *testing.T) {
 		t.Error("expected config file to be created")
 	}
 }
+
+func TestSaveSettings(t *testing.T) {
+	t.Run("JSON file keeps its other settings", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.json")
+		original := `{"data_file": "/tmp/tasks.json", "keymap": {"add": "n"}, "skip_confirm": ["delete"]}`
+		if err := os.WriteFile(configFile, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadConfig(configFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.SortMode = "due"
+		cfg.GroupBy = "project"
+		cfg.SkipConfirm = nil
+		if err := cfg.SaveSettings(configFile); err != nil {
+			t.Fatalf("expected no error saving settings, got %v", err)
+		}
+
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "theme") || strings.Contains(string(data), "undo_limit") {
+			t.Errorf("expected the defaults not to be written to the file, got %s", data)
+		}
+		saved, err := LoadConfig(configFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.DataFile != "/tmp/tasks.json" || saved.KeyMap.Add != "n" {
+			t.Errorf("expected the other settings to be kept, got %+v", saved)
+		}
+		if saved.SortMode != "due" || saved.GroupBy != "project" || len(saved.SkipConfirm) != 0 {
+			t.Errorf("expected the changed settings to be saved, got %+v", saved)
+		}
+	})
+
+	t.Run("YAML file keeps its comments", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		original := "# my tasks\ndata_file: /tmp/tasks.json\nsort_mode: priority # for now\n"
+		if err := os.WriteFile(configFile, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadConfig(configFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.SortMode = "name"
+		cfg.SortReverse = true
+		if err := cfg.SaveSettings(configFile); err != nil {
+			t.Fatalf("expected no error saving settings, got %v", err)
+		}
+
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "# my tasks\ndata_file: /tmp/tasks.json\nsort_mode: name # for now\nsort_reverse: true\ngroup_by: none\n"
+		if string(data) != expected {
+			t.Errorf("expected\n%s\ngot\n%s", expected, data)
+		}
+	})
+
+	t.Run("missing file is created", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "td", "config.json")
+		cfg := DefaultConfig()
+		cfg.GroupBy = "tag"
+		if err := cfg.SaveSettings(configFile); err != nil {
+			t.Fatalf("expected no error saving settings, got %v", err)
+		}
+		saved, err := LoadConfig(configFile)
+		if err != nil || saved.GroupBy != "tag" {
+			t.Errorf("expected the grouping to be saved, got %v, %v", saved, err)
+		}
+	})
+
+	t.Run("invalid file is left alone", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(configFile, []byte("{oops"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := DefaultConfig().SaveSettings(configFile); err == nil {
+			t.Error("expected an error for an invalid config file")
+		}
+		if data, _ := os.ReadFile(configFile); string(data) != "{oops" {
+			t.Errorf("expected the file to be unchanged, got %s", data)
+		}
+	})
+}
+
+func TestGetConfigPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "td")
+
+	if path := GetConfigPath(); path != filepath.Join(dir, "config.json") {
+		t.Errorf("expected config.json without a config file, got %s", path)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("undo_limit: 20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path := GetConfigPath(); path != filepath.Join(dir, "config.yaml") {
+		t.Errorf("expected the YAML config file to be found, got %s", path)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path := GetConfigPath(); path != filepath.Join(dir, "config.json") {
+		t.Errorf("expected config.json to come first, got %s", path)
+	}
+}
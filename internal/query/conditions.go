@@ -0,0 +1,183 @@
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// keywordCondition returns the condition of a keyword such as "blocked".
+func keywordCondition(word string) (node, error) {
+	switch strings.ToLower(word) {
+	case "done":
+		return condNode(func(t *task.Task, _ Env) bool { return t.IsDone }), nil
+	case "cancelled":
+		return condNode(func(t *task.Task, _ Env) bool { return t.IsCancelled() }), nil
+	case "blocked":
+		return condNode(isBlocked), nil
+	case "ready":
+		return condNode(func(t *task.Task, env Env) bool {
+			status := t.CurrentStatus()
+			return (status == task.StatusTodo || status == task.StatusInProgress) && !isBlocked(t, env)
+		}), nil
+	case "recurring":
+		return condNode(func(t *task.Task, _ Env) bool { return t.Recurrence != nil }), nil
+	case "overdue":
+		return condNode(func(t *task.Task, env Env) bool {
+			return !t.IsDone && t.DueAt != nil && t.DueAt.Before(task.StartOfDay(env.Now))
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown condition %q", word)
+}
+
+// isBlocked reports whether a task is blocked, by its status or by unfinished tasks.
+func isBlocked(t *task.Task, env Env) bool {
+	return t.CurrentStatus() == task.StatusBlocked || env.Tasks != nil && env.Tasks.IsBlocked(t.ID)
+}
+
+// fieldCondition returns the condition comparing a field with a value.
+func fieldCondition(field, op, value string) (node, error) {
+	switch strings.ToLower(field) {
+	case "tag":
+		return equality(field, op, func(t *task.Task, _ Env) bool { return t.HasTag(value) })
+	case "project":
+		project := task.NormalizeProject(value)
+		return equality(field, op, func(t *task.Task, _ Env) bool { return t.Project == project })
+	case "status":
+		status, err := task.ParseStatus(value)
+		if err != nil {
+			return nil, err
+		}
+		return equality(field, op, func(t *task.Task, _ Env) bool { return t.CurrentStatus() == status })
+	case "name":
+		text := strings.ToLower(value)
+		return equality(field, op, func(t *task.Task, _ Env) bool {
+			return strings.Contains(strings.ToLower(t.Name), text)
+		})
+	case "prio", "priority":
+		priority, err := task.ParsePriority(value)
+		if err != nil {
+			return nil, err
+		}
+		return condNode(func(t *task.Task, _ Env) bool {
+			return compare(int(t.Priority)-int(priority), op)
+		}), nil
+	case "id":
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task ID %q", value)
+		}
+		return condNode(func(t *task.Task, _ Env) bool { return compare(t.ID-id, op) }), nil
+	case "due":
+		return timeCondition(value, op, func(t *task.Task) *time.Time { return t.DueAt }, false)
+	case "created":
+		return timeCondition(value, op, func(t *task.Task) *time.Time { return &t.CreatedAt }, true)
+	}
+	return nil, fmt.Errorf("unknown field %q", field)
+}
+
+// equality returns a condition for a field that can only be compared with
+// :, = or !=.
+func equality(field, op string, match condNode) (node, error) {
+	switch op {
+	case ":", "=":
+		return match, nil
+	case "!=":
+		return notNode{match}, nil
+	}
+	return nil, fmt.Errorf("%s can only be compared with :, = or !=", field)
+}
+
+// timeCondition returns a condition comparing a date field with a day or a
+// duration. Durations are measured back from now for past dates and forward
+// from now otherwise.
+func timeCondition(value, op string, field func(*task.Task) *time.Time, past bool) (node, error) {
+	if duration, ok := parseDuration(value); ok {
+		if op == ":" {
+			op = "<="
+		}
+		return condNode(func(t *task.Task, env Env) bool {
+			at := field(t)
+			if at == nil {
+				return false
+			}
+			distance := at.Sub(env.Now)
+			if past {
+				distance = -distance
+			}
+			return compare(cmp.Compare(distance, duration), op)
+		}), nil
+	}
+
+	day, err := parseDay(value)
+	if err != nil {
+		return nil, err
+	}
+	return condNode(func(t *task.Task, env Env) bool {
+		at := field(t)
+		if at == nil {
+			return false
+		}
+		return compare(task.StartOfDay(*at).Compare(day(env.Now)), op)
+	}), nil
+}
+
+// parseDuration parses a number of hours, days or weeks such as "7d".
+func parseDuration(value string) (time.Duration, bool) {
+	if len(value) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	switch strings.ToLower(value[len(value)-1:]) {
+	case "h":
+		return time.Duration(n) * time.Hour, true
+	case "d":
+		return time.Duration(n) * 24 * time.Hour, true
+	case "w":
+		return time.Duration(n) * 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// parseDay parses a day relative to the time the query is evaluated.
+func parseDay(value string) (func(now time.Time) time.Time, error) {
+	switch strings.ToLower(value) {
+	case "today":
+		return task.StartOfDay, nil
+	case "tomorrow":
+		return func(now time.Time) time.Time { return task.StartOfDay(now).AddDate(0, 0, 1) }, nil
+	case "yesterday":
+		return func(now time.Time) time.Time { return task.StartOfDay(now).AddDate(0, 0, -1) }, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, use today, tomorrow, yesterday, 2006-01-02 or a duration like 7d", value)
+	}
+	return func(time.Time) time.Time { return date }, nil
+}
+
+// compare reports whether a difference (negative, zero or positive) satisfies
+// an operator.
+func compare(diff int, op string) bool {
+	switch op {
+	case ">=":
+		return diff >= 0
+	case "<=":
+		return diff <= 0
+	case "!=":
+		return diff != 0
+	case ">":
+		return diff > 0
+	case "<":
+		return diff < 0
+	default:
+		return diff == 0
+	}
+}
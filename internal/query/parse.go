@@ -0,0 +1,208 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind is the kind of a token of a query.
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenOperator
+	tokenOpen
+	tokenClose
+	tokenEnd
+)
+
+// token is a word, operator or parenthesis of a query.
+type token struct {
+	kind   tokenKind
+	text   string
+	quoted bool // A quoted word is never a keyword
+}
+
+// operators are the comparison operators, longest first so "<=" is not read
+// as "<".
+var operators = []string{">=", "<=", "!=", ">", "<", "=", ":"}
+
+// lex splits a query into tokens. It reads the query rune by rune, so words
+// may contain any letters.
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")"})
+			i++
+		case r == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("missing closing quote in %q", s)
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: s[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case isOperatorStart(r):
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(s[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unknown operator %q", s[i:i+1])
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op})
+			i += len(op)
+		default:
+			start := i
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if isDelimiter(r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: s[start:i]})
+		}
+	}
+	return append(tokens, token{kind: tokenEnd}), nil
+}
+
+// isOperatorStart reports whether r starts a comparison operator.
+func isOperatorStart(r rune) bool {
+	return strings.ContainsRune("<>=!:", r)
+}
+
+// isDelimiter reports whether r ends a word.
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' || isOperatorStart(r)
+}
+
+// parser is a recursive descent parser over the tokens of a query.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses a query. An empty query matches every task.
+func Parse(s string) (*Query, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEnd {
+		return &Query{source: s, root: allNode{}}, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q", next.text)
+	}
+	return &Query{source: strings.TrimSpace(s), root: root}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword reports whether t is the unquoted word keyword.
+func isKeyword(t token, keyword string) bool {
+	return t.kind == tokenWord && !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+// parseOr parses conditions joined by "or".
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses conditions joined by "and" or written next to each other.
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		next := p.peek()
+		if isKeyword(next, "and") {
+			p.next()
+		} else if next.kind == tokenEnd || next.kind == tokenClose || isKeyword(next, "or") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+// parseUnary parses a negation, a group in parentheses or a condition.
+func (p *parser) parseUnary() (node, error) {
+	t := p.next()
+	switch {
+	case isKeyword(t, "not"):
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case t.kind == tokenOpen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return inner, nil
+	case t.kind == tokenWord:
+		if p.peek().kind != tokenOperator {
+			return keywordCondition(t.text)
+		}
+		op := p.next().text
+		value := p.next()
+		if value.kind != tokenWord {
+			return nil, fmt.Errorf("missing value after %s%s", t.text, op)
+		}
+		return fieldCondition(t.text, op, value.text)
+	case t.kind == tokenEnd:
+		return nil, fmt.Errorf("unexpected end of query")
+	default:
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+}
@@ -0,0 +1,107 @@
+// Package query provides a small query language for selecting tasks, e.g.
+// `prio>=medium and tag:backend and created<7d and not done`.
+//
+// A query is a list of conditions joined by "and" (which may be left out) and
+// "or", negated with "not" and grouped with parentheses; "and" binds tighter
+// than "or". Conditions are either keywords:
+//
+//	done        completed or cancelled
+//	cancelled   cancelled
+//	blocked     blocked by unfinished tasks or with status blocked
+//	ready       todo or in progress and not blocked
+//	recurring   repeats
+//	overdue     not done and due before today
+//
+// or comparisons of a field with a value, written with or without spaces
+// around the operator (:, =, !=, <, <=, > or >=):
+//
+//	tag:ops              tagged #ops
+//	project:website      in project +website
+//	name:deploy          name contains "deploy"; quote values with spaces
+//	prio>=medium         priority: none, low, medium or high
+//	status:waiting       status: todo, in_progress, waiting, blocked, done or cancelled
+//	id<=10               task ID
+//	due<=today           due date: today, tomorrow, yesterday or 2006-01-02
+//	created<7d           age or time left: a number of hours, days or weeks
+//
+// Dates compare calendar days. Durations compare how long ago a task was
+// created, or how long until it is due, so `due<3d` selects tasks due within
+// the next three days or overdue; `:` with a duration means "within". Tasks
+// without a due date never match a due date comparison.
+package query
+
+import (
+	"time"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// Env is what a query is evaluated against.
+type Env struct {
+	// Tasks resolves blockers; may be nil when no condition needs it.
+	Tasks *task.TaskManager
+	// Now is the time relative dates and durations are measured from.
+	Now time.Time
+}
+
+// Query is a parsed query.
+type Query struct {
+	source string
+	root   node
+}
+
+// node is a part of a query that matches tasks.
+type node interface {
+	match(t *task.Task, env Env) bool
+}
+
+type (
+	andNode struct{ left, right node }
+	orNode  struct{ left, right node }
+	notNode struct{ operand node }
+	// allNode matches every task; it is the query of an empty string.
+	allNode struct{}
+	// condNode is a single condition.
+	condNode func(t *task.Task, env Env) bool
+)
+
+func (n andNode) match(t *task.Task, env Env) bool {
+	return n.left.match(t, env) && n.right.match(t, env)
+}
+func (n orNode) match(t *task.Task, env Env) bool {
+	return n.left.match(t, env) || n.right.match(t, env)
+}
+func (n notNode) match(t *task.Task, env Env) bool  { return !n.operand.match(t, env) }
+func (allNode) match(*task.Task, Env) bool          { return true }
+func (n condNode) match(t *task.Task, env Env) bool { return n(t, env) }
+
+// MustParse parses a query and panics when it is invalid. It is meant for
+// queries that are part of the program.
+func MustParse(s string) *Query {
+	q, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Match reports whether the task matches the query.
+func (q *Query) Match(t *task.Task, env Env) bool {
+	return q.root.match(t, env)
+}
+
+// Filter returns the tasks that match the query, in their order.
+func (q *Query) Filter(tasks []*task.Task, env Env) []*task.Task {
+	matched := []*task.Task{}
+	for _, t := range tasks {
+		if q.Match(t, env) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+// String returns the query as it was written.
+func (q *Query) String() string {
+	return q.source
+}
@@ -0,0 +1,141 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/voioo/td/internal/task"
+)
+
+func TestQuery(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+	nextWeek := now.AddDate(0, 0, 7)
+
+	tm := task.NewTaskManager(nil, nil, 0)
+	deploy := tm.AddTask("Deploy API")
+	deploy.Priority = task.PriorityHigh
+	deploy.Tags = []string{"backend", "ops"}
+	deploy.DueAt = &yesterday
+	deploy.CreatedAt = now.AddDate(0, 0, -10)
+	docs := tm.AddTask("write docs")
+	docs.Project = "website"
+	docs.DueAt = &nextWeek
+	docs.CreatedAt = now.AddDate(0, 0, -2)
+	docs.Status = task.StatusInProgress
+	review := tm.AddTask("review")
+	review.Priority = task.PriorityMedium
+	review.Tags = []string{"backend"}
+	review.CreatedAt = now.Add(-time.Hour)
+	if err := tm.AddDependency(review.ID, deploy.ID); err != nil {
+		t.Fatal(err)
+	}
+	shipped := tm.AddTask("ship v1")
+	shipped.Priority = task.PriorityMedium
+	shipped.Tags = []string{"backend"}
+	shipped.CreatedAt = now.AddDate(0, 0, -1)
+	tm.CompleteTask(shipped.ID)
+	tasks := []*task.Task{deploy, docs, review, shipped}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"", "Deploy API, write docs, review, ship v1"},
+		{"prio>=medium and tag:backend and created<7d and not done", "review"},
+		{"prio >= medium tag:backend", "Deploy API, review, ship v1"},
+		{"tag:ops or project:+website", "Deploy API, write docs"},
+		{"tag:backend and (blocked or done)", "review, ship v1"},
+		{"not (tag:backend or done)", "write docs"},
+		{"tag != backend", "write docs"},
+		{"ready", "Deploy API, write docs"},
+		{"done", "ship v1"},
+		{"overdue", "Deploy API"},
+		{"due<=today", "Deploy API"},
+		{"due>tomorrow", "write docs"},
+		{"due<3d", "Deploy API"},
+		{"due:2024-03-13", "write docs"},
+		{"created:1d", "review, ship v1"},
+		{"created<1d", "review"},
+		{"created>=yesterday", "review, ship v1"},
+		{`name:"api"`, "Deploy API"},
+		{"id<=2", "Deploy API, write docs"},
+		{"priority:none", "write docs"},
+		{"status:in-progress", "write docs"},
+		{"status:todo", "Deploy API, review"},
+		{"status != done", "Deploy API, write docs, review"},
+		{"cancelled", ""},
+		{"AND", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if tt.query == "AND" {
+				if err == nil {
+					t.Error("expected a lone and to be an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var got []string
+			for _, tk := range q.Filter(tasks, Env{Tasks: tm, Now: now}) {
+				got = append(got, tk.Name)
+			}
+			if strings.Join(got, ", ") != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, strings.Join(got, ", "))
+			}
+		})
+	}
+}
+
+func TestQueryNonASCII(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	cafe := tm.AddTask("Café voilà")
+	cafe.Tags = []string{"münchen"}
+	report := tm.AddTask("Отчёт за квартал")
+	report.Project = "работа"
+	tasks := []*task.Task{cafe, report}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"name:voilà", "Café voilà"},
+		{"name:Отчёт", "Отчёт за квартал"},
+		{"tag:münchen or project:работа", "Café voilà, Отчёт за квартал"},
+		{"project:+работа and not tag:münchen", "Отчёт за квартал"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var got []string
+			for _, tk := range q.Filter(tasks, Env{Tasks: tm, Now: time.Now()}) {
+				got = append(got, tk.Name)
+			}
+			if strings.Join(got, ", ") != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, strings.Join(got, ", "))
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, invalid := range []string{
+		"color:red", "prio>=urgent", "due<soon", "tag>ops", "urgent",
+		"blocked and not", "(tag:ops", "tag:ops)", "tag:", `name:"open`, "prio!medium", "id:x", "status:later",
+	} {
+		if _, err := Parse(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+
+	if q := MustParse("  tag:ops  "); q.String() != "tag:ops" {
+		t.Errorf("expected the query text to be kept, got %q", q.String())
+	}
+}
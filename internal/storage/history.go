@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/voioo/td/internal/logger"
+	"github.com/voioo/td/internal/task"
+)
+
+// historyFile is the on-disk format of the undo history. DataChecksum is the
+// checksum of the data file the history was saved with.
+type historyFile struct {
+	Version      int              `json:"version"`
+	DataChecksum string           `json:"data_checksum"`
+	History      task.UndoHistory `json:"history"`
+}
+
+// historyFileVersion is the current version of the undo history format.
+const historyFileVersion = 1
+
+// HistoryPath returns the path of the undo history kept next to the data
+// file, e.g. ~/.td.undo.json for ~/.td.json.
+func (r *FileRepository) HistoryPath() string {
+	ext := filepath.Ext(r.filePath)
+	return strings.TrimSuffix(r.filePath, ext) + ".undo" + ext
+}
+
+// LoadHistory loads the undo history saved with the data file. An empty
+// history is returned when there is none, or when the data file was changed
+// by something else since the history was saved.
+func (r *FileRepository) LoadHistory() (task.UndoHistory, error) {
+	data, err := os.ReadFile(r.HistoryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return task.UndoHistory{}, nil
+		}
+		return task.UndoHistory{}, fmt.Errorf("failed to read undo history: %w", err)
+	}
+
+	var stored historyFile
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return task.UndoHistory{}, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+	if stored.Version != historyFileVersion {
+		logger.Warn("Ignoring undo history with unknown version", logger.F("version", stored.Version))
+		return task.UndoHistory{}, nil
+	}
+
+	checksum, err := r.dataChecksum()
+	if err != nil {
+		return task.UndoHistory{}, err
+	}
+	if stored.DataChecksum != checksum {
+		logger.Warn("Data file changed since the undo history was saved, discarding it")
+		return task.UndoHistory{}, nil
+	}
+
+	logger.Debug("Loaded undo history",
+		logger.F("undo", len(stored.History.Undo)),
+		logger.F("redo", len(stored.History.Redo)))
+	return stored.History, nil
+}
+
+// SaveHistory saves the undo history together with the checksum of the data
+// file as it is now, so it should be called right after SaveTasks.
+func (r *FileRepository) SaveHistory(history task.UndoHistory) error {
+	checksum, err := r.dataChecksum()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(historyFile{
+		Version:      historyFileVersion,
+		DataChecksum: checksum,
+		History:      history,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal undo history: %w", err)
+	}
+
+	if err := os.WriteFile(r.HistoryPath(), data, 0644); err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("%w: %v", ErrPermissionDenied, err)
+		}
+		return fmt.Errorf("failed to write undo history: %w", err)
+	}
+	return nil
+}
+
+// dataChecksum returns the SHA-256 checksum of the data file contents, which
+// is empty when the file does not exist.
+func (r *FileRepository) dataChecksum() (string, error) {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read data file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
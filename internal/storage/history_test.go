@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/voioo/td/internal/task"
+)
+
+func TestHistory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "td-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataFile := filepath.Join(tempDir, "test.json")
+	repo := NewRepository(dataFile)
+
+	tasks := []*task.Task{{ID: 1, Name: "Active Task", CreatedAt: testTime()}}
+	history := task.UndoHistory{
+		Undo: []task.UndoEntry{{Command: &task.AddCommand{Task: tasks[0]}, At: testTime()}},
+	}
+
+	t.Run("history path", func(t *testing.T) {
+		expected := filepath.Join(tempDir, "test.undo.json")
+		if repo.HistoryPath() != expected {
+			t.Errorf("expected history path %s, got %s", expected, repo.HistoryPath())
+		}
+	})
+
+	t.Run("load missing history", func(t *testing.T) {
+		loaded, err := repo.LoadHistory()
+		if err != nil {
+			t.Errorf("expected no error for missing history, got %v", err)
+		}
+		if len(loaded.Undo) != 0 || len(loaded.Redo) != 0 {
+			t.Error("expected empty history")
+		}
+	})
+
+	t.Run("save and load history", func(t *testing.T) {
+		if err := repo.SaveTasks(tasks, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.SaveHistory(history); err != nil {
+			t.Fatalf("expected no error saving history, got %v", err)
+		}
+
+		loaded, err := repo.LoadHistory()
+		if err != nil {
+			t.Fatalf("expected no error loading history, got %v", err)
+		}
+		if len(loaded.Undo) != 1 || loaded.Undo[0].Command.Kind() != task.ActionTypeAdd {
+			t.Errorf("expected saved undo action, got %+v", loaded.Undo)
+		}
+	})
+
+	t.Run("discard history when data changed", func(t *testing.T) {
+		if err := repo.SaveTasks(tasks, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.SaveHistory(history); err != nil {
+			t.Fatal(err)
+		}
+
+		// Another program edits the data file
+		changed := []*task.Task{{ID: 1, Name: "Edited Elsewhere", CreatedAt: testTime()}}
+		if err := repo.SaveTasks(changed, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded, err := repo.LoadHistory()
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(loaded.Undo) != 0 {
+			t.Error("expected history to be discarded")
+		}
+	})
+
+	t.Run("ignore history of another version", func(t *testing.T) {
+		if err := repo.SaveTasks(tasks, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(repo.HistoryPath(), []byte(`{"version": 2, "history": {}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded, err := repo.LoadHistory()
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(loaded.Undo) != 0 || len(loaded.Redo) != 0 {
+			t.Error("expected history of another version to be ignored")
+		}
+	})
+
+	t.Run("invalid history", func(t *testing.T) {
+		if err := os.WriteFile(repo.HistoryPath(), []byte("not json"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.LoadHistory(); err == nil {
+			t.Error("expected error for invalid history file")
+		}
+	})
+}
@@ -142,7 +142,10 @@ func (r *FileRepository) SaveTasks(tasks []*task.Task, doneTasks []*task.Task) e
 	return nil
 }
 
-// validateTask checks if a task has valid data.
+// validateTask checks if a task has valid data. It runs on loading as well as
+// on saving, so it only rejects tasks td cannot work with: limits on what can
+// be entered, such as task.MaxNameLength, are checked where it is entered, and
+// files written by older versions or edited by hand keep loading.
 func (r *FileRepository) validateTask(t *task.Task) error {
 	if t == nil {
 		return errors.New("task is nil")
@@ -166,6 +169,27 @@ func (r *FileRepository) validateTask(t *task.Task) error {
 	if t.CreatedAt.After(time.Now().Add(24 * time.Hour)) {
 		return errors.New("task creation time cannot be more than 24 hours in the future")
 	}
+	for _, blockerID := range t.BlockedBy {
+		if blockerID <= 0 || blockerID == t.ID {
+			return errors.New("invalid blocked-by task ID")
+		}
+	}
+	if t.Recurrence != nil {
+		if err := t.Recurrence.Validate(); err != nil {
+			return fmt.Errorf("invalid recurrence: %w", err)
+		}
+	}
+	running := 0
+	for _, entry := range t.TimeEntries {
+		if entry.End == nil {
+			running++
+		} else if entry.End.Before(entry.Start) {
+			return errors.New("time entry cannot end before it starts")
+		}
+	}
+	if running > 1 {
+		return errors.New("task cannot have more than one running timer")
+	}
 	return nil
 }
 
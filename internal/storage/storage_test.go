@@ -3,6 +3,7 @@ package storage
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -156,6 +157,45 @@ func TestRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("load hand-edited file with a long name", func(t *testing.T) {
+		longName := strings.Repeat("x", 300)
+		data := `[{"id": 1, "name": "` + longName + `", "created_at": "2024-01-01T10:00:00Z",
+			"notes": "` + strings.Repeat("n", task.MaxNotesLength+1) + `",
+			"tags": ["Work Stuff"], "project": "Home!", "status": "someday"}]`
+		file := filepath.Join(tempDir, "hand-edited.json")
+		if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		repo := NewRepository(file)
+		tasks, _, _, err := repo.LoadTasks()
+		if err != nil {
+			t.Fatalf("expected the file to load, got %v", err)
+		}
+		if len(tasks) != 1 || tasks[0].Name != longName {
+			t.Fatalf("expected the task with the 300 character name, got %v", tasks)
+		}
+		if err := repo.SaveTasks(tasks, nil); err != nil {
+			t.Errorf("expected the loaded task to be saved again, got %v", err)
+		}
+	})
+
+	t.Run("validate invalid task - two running timers", func(t *testing.T) {
+		invalidTask := &task.Task{
+			ID:          1,
+			Name:        "Task",
+			CreatedAt:   testTime(),
+			TimeEntries: []task.TimeEntry{{Start: testTime()}, {Start: testTime().Add(time.Hour)}},
+		}
+
+		repo := NewRepository(dataFile)
+		err := repo.SaveTasks([]*task.Task{invalidTask}, []*task.Task{})
+
+		if err == nil {
+			t.Error("expected error for task with two running timers")
+		}
+	})
+
 	t.Run("load corrupted data", func(t *testing.T) {
 		// Write invalid JSON to the file
 		err := os.WriteFile(dataFile, []byte("invalid json"), 0644)
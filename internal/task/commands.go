@@ -0,0 +1,918 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Command is a reversible change to the tasks of a TaskManager. Commands are
+// recorded after the change was made, so Apply is only called to redo it.
+type Command interface {
+	// Kind identifies the command, also in stored undo history.
+	Kind() ActionType
+	// Apply makes the change (again).
+	Apply(tm *TaskManager) error
+	// Revert undoes the change.
+	Revert(tm *TaskManager) error
+	// Describe summarizes the change, e.g. `priority #7 "deploy" low → high`.
+	Describe(tm *TaskManager) string
+}
+
+// commandKinds creates an empty command of each kind for decoding. New
+// commands only need an entry here to be stored in the undo history.
+var commandKinds = map[ActionType]func() Command{
+	ActionTypeAdd:        func() Command { return &AddCommand{} },
+	ActionTypeDelete:     func() Command { return &DeleteCommand{} },
+	ActionTypeComplete:   func() Command { return &CompleteCommand{} },
+	ActionTypeUncomplete: func() Command { return &UncompleteCommand{} },
+	ActionTypeEdit:       func() Command { return &EditCommand{} },
+	ActionTypePriority:   func() Command { return &PriorityCommand{} },
+	ActionTypeDependency: func() Command { return &DependencyCommand{} },
+	ActionTypeRecurrence: func() Command { return &RecurrenceCommand{} },
+	ActionTypeNotes:      func() Command { return &NotesCommand{} },
+	ActionTypeTags:       func() Command { return &TagsCommand{} },
+	ActionTypeProject:    func() Command { return &ProjectCommand{} },
+	ActionTypeDue:        func() Command { return &DueCommand{} },
+	ActionTypeStatus:     func() Command { return &StatusCommand{} },
+	ActionTypeMove:       func() Command { return &ReorderCommand{} },
+	ActionTypeTimer:      func() Command { return &TimerCommand{} },
+	ActionTypeBatch:      func() Command { return &BatchCommand{} },
+}
+
+// Commands is a list of commands that is encoded to JSON together with the
+// kind of each command.
+type Commands []Command
+
+// storedCommand is the JSON form of a single command.
+type storedCommand struct {
+	Kind    ActionType      `json:"kind"`
+	Command json.RawMessage `json:"command"`
+}
+
+// encodeCommand converts a command to its JSON form.
+func encodeCommand(c Command) (storedCommand, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return storedCommand{}, fmt.Errorf("failed to encode %s command: %w", c.Kind(), err)
+	}
+	return storedCommand{Kind: c.Kind(), Command: data}, nil
+}
+
+// decodeCommand converts the JSON form of a command back.
+func decodeCommand(s storedCommand) (Command, error) {
+	newCommand, ok := commandKinds[s.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown command kind %q", s.Kind)
+	}
+	c := newCommand()
+	if err := json.Unmarshal(s.Command, c); err != nil {
+		return nil, fmt.Errorf("invalid %s command: %w", s.Kind, err)
+	}
+	if holder, ok := c.(taskHolder); ok {
+		for _, t := range holder.heldTasks() {
+			if t == nil {
+				return nil, fmt.Errorf("invalid %s command: missing task", s.Kind)
+			}
+		}
+	}
+	return c, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (cs Commands) MarshalJSON() ([]byte, error) {
+	stored := make([]storedCommand, 0, len(cs))
+	for _, c := range cs {
+		s, err := encodeCommand(c)
+		if err != nil {
+			return nil, err
+		}
+		stored = append(stored, s)
+	}
+	return json.Marshal(stored)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (cs *Commands) UnmarshalJSON(data []byte) error {
+	var stored []storedCommand
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	commands := make(Commands, 0, len(stored))
+	for _, s := range stored {
+		c, err := decodeCommand(s)
+		if err != nil {
+			return err
+		}
+		commands = append(commands, c)
+	}
+	*cs = commands
+	return nil
+}
+
+// taskHolder is implemented by commands that hold on to tasks which may be
+// missing from the task manager, such as deleted ones.
+type taskHolder interface {
+	heldTasks() []*Task
+}
+
+// findTask returns the task a command refers to.
+func (tm *TaskManager) findTask(id int) (*Task, error) {
+	if t := tm.FindTaskByID(id); t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: #%d", ErrTaskNotFound, id)
+}
+
+// describeTask refers to a task by ID and, if it can be found, by name.
+func (tm *TaskManager) describeTask(id int) string {
+	if t := tm.FindTaskByID(id); t != nil {
+		return describeTask(t)
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
+// describeTask refers to a task by ID and name.
+func describeTask(t *Task) string {
+	return fmt.Sprintf("#%d %q", t.ID, t.Name)
+}
+
+// orNone returns s, or "none" when it is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// formatRecurrence describes a recurrence rule, which may be nil.
+func formatRecurrence(r *Recurrence) string {
+	if r == nil {
+		return "none"
+	}
+	return r.String()
+}
+
+// formatDue describes a due date, which may be nil.
+func formatDue(due *time.Time) string {
+	if due == nil {
+		return "none"
+	}
+	return due.Format("2006-01-02")
+}
+
+// removeTask removes the task with the given ID and returns it.
+func (tm *TaskManager) removeTask(id int) (*Task, error) {
+	if t := tm.deleteTask(id); t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: #%d", ErrTaskNotFound, id)
+}
+
+// restoreTask puts a removed task back into the active or completed list.
+func (tm *TaskManager) restoreTask(t *Task) error {
+	if tm.FindTaskByID(t.ID) != nil {
+		return fmt.Errorf("task #%d already exists", t.ID)
+	}
+	if t.IsDone {
+		tm.doneTasks = append(tm.doneTasks, t)
+	} else {
+		tm.tasks = append(tm.tasks, t)
+	}
+	tm.reserveID(t.ID)
+	tm.sortTasks()
+	return nil
+}
+
+// moveTask moves a task between the active and completed lists without
+// recording it in the task's history.
+func (tm *TaskManager) moveTask(id int, done bool) (*Task, error) {
+	t, err := tm.removeTask(id)
+	if err != nil {
+		return nil, err
+	}
+	t.IsDone = done
+	return t, tm.restoreTask(t)
+}
+
+// undoneChange keeps the history of a task in step with undo and redo. Undoing
+// a change takes its entry out of the task's history and redoing the change
+// puts that entry back, so replaying a command never records it twice.
+type undoneChange struct {
+	// Change is the history entry of the change while it is undone.
+	Change *Change `json:"change,omitempty"`
+}
+
+// undo takes the most recent change of the given type out of the history of t.
+func (u *undoneChange) undo(t *Task, actionType ActionType) {
+	u.Change = t.takeChange(actionType)
+}
+
+// redo puts the change taken by undo back into the history of t, if the
+// command found its task.
+func (u *undoneChange) redo(t *Task, err error) error {
+	if err == nil && u.Change != nil {
+		t.restoreChange(*u.Change)
+		u.Change = nil
+	}
+	return err
+}
+
+// AddCommand records the addition of a task.
+type AddCommand struct {
+	Task *Task `json:"task"`
+}
+
+// Kind implements Command.
+func (c *AddCommand) Kind() ActionType { return ActionTypeAdd }
+
+// Apply implements Command.
+func (c *AddCommand) Apply(tm *TaskManager) error {
+	return tm.restoreTask(c.Task)
+}
+
+// Revert implements Command.
+func (c *AddCommand) Revert(tm *TaskManager) error {
+	t, err := tm.removeTask(c.Task.ID)
+	if err != nil {
+		return err
+	}
+	c.Task = t
+	return nil
+}
+
+// Describe implements Command.
+func (c *AddCommand) Describe(tm *TaskManager) string {
+	return "added " + describeTask(c.Task)
+}
+
+func (c *AddCommand) heldTasks() []*Task { return []*Task{c.Task} }
+
+// DeleteCommand records the deletion of a task.
+type DeleteCommand struct {
+	Task *Task `json:"task"`
+}
+
+// Kind implements Command.
+func (c *DeleteCommand) Kind() ActionType { return ActionTypeDelete }
+
+// Apply implements Command.
+func (c *DeleteCommand) Apply(tm *TaskManager) error {
+	t, err := tm.removeTask(c.Task.ID)
+	if err != nil {
+		return err
+	}
+	c.Task = t
+	return nil
+}
+
+// Revert implements Command.
+func (c *DeleteCommand) Revert(tm *TaskManager) error {
+	return tm.restoreTask(c.Task)
+}
+
+// Describe implements Command.
+func (c *DeleteCommand) Describe(tm *TaskManager) string {
+	return "deleted " + describeTask(c.Task)
+}
+
+func (c *DeleteCommand) heldTasks() []*Task { return []*Task{c.Task} }
+
+// CompleteCommand records the completion of a task.
+type CompleteCommand struct {
+	TaskID int `json:"task_id"`
+	// CompletedAt is when the task was completed, kept when it is redone.
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Occurrence is the next occurrence of a recurring task while the
+	// completion that spawned it is undone, restored when it is redone.
+	Occurrence *Task `json:"occurrence,omitempty"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *CompleteCommand) Kind() ActionType { return ActionTypeComplete }
+
+// Apply implements Command. Redoing the completion of a recurring task brings
+// back the occurrence it spawned before, with the same ID.
+func (c *CompleteCommand) Apply(tm *TaskManager) error {
+	if c.Occurrence != nil && tm.FindTaskByID(c.Occurrence.ID) != nil {
+		return fmt.Errorf("task #%d already exists", c.Occurrence.ID)
+	}
+	completedAt := time.Now()
+	if c.CompletedAt != nil {
+		completedAt = *c.CompletedAt
+	}
+	t := tm.completeTask(c.TaskID, completedAt)
+	if t == nil {
+		return fmt.Errorf("task #%d cannot be completed", c.TaskID)
+	}
+	if c.Occurrence != nil {
+		if err := tm.restoreOccurrence(t, c.Occurrence); err != nil {
+			return err
+		}
+		c.Occurrence = nil
+	} else {
+		tm.spawnNextOccurrence(t, completedAt)
+	}
+	return c.redo(t, nil)
+}
+
+// Revert implements Command. The next occurrence spawned by completing a
+// recurring task is removed again.
+func (c *CompleteCommand) Revert(tm *TaskManager) error {
+	t, err := tm.moveTask(c.TaskID, false)
+	if err != nil {
+		return err
+	}
+	t.CompletedAt = nil
+	c.Occurrence = tm.removeSpawnedOccurrence(t)
+	c.undo(t, c.Kind())
+	return nil
+}
+
+// Describe implements Command.
+func (c *CompleteCommand) Describe(tm *TaskManager) string {
+	return "completed " + tm.describeTask(c.TaskID)
+}
+
+func (c *CompleteCommand) heldTasks() []*Task {
+	if c.Occurrence == nil {
+		return nil
+	}
+	return []*Task{c.Occurrence}
+}
+
+// UncompleteCommand records the reopening of a completed task.
+type UncompleteCommand struct {
+	TaskID int `json:"task_id"`
+	// CompletedAt is when the task was completed, restored when it is undone.
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *UncompleteCommand) Kind() ActionType { return ActionTypeUncomplete }
+
+// Apply implements Command.
+func (c *UncompleteCommand) Apply(tm *TaskManager) error {
+	t := tm.uncompleteTask(c.TaskID)
+	if t == nil {
+		return fmt.Errorf("%w: #%d", ErrTaskNotFound, c.TaskID)
+	}
+	return c.redo(t, nil)
+}
+
+// Revert implements Command.
+func (c *UncompleteCommand) Revert(tm *TaskManager) error {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return err
+	}
+	t.CompletedAt = c.CompletedAt
+	if _, err := tm.moveTask(c.TaskID, true); err != nil {
+		return err
+	}
+	c.undo(t, c.Kind())
+	return nil
+}
+
+// Describe implements Command.
+func (c *UncompleteCommand) Describe(tm *TaskManager) string {
+	return "reopened " + tm.describeTask(c.TaskID)
+}
+
+// EditCommand records the renaming of a task.
+type EditCommand struct {
+	TaskID int    `json:"task_id"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *EditCommand) Kind() ActionType { return ActionTypeEdit }
+
+// Apply implements Command.
+func (c *EditCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *EditCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	if err == nil && c.Old != c.New {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *EditCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("renamed #%d %q → %q", c.TaskID, c.Old, c.New)
+}
+
+// set renames the task.
+func (c *EditCommand) set(tm *TaskManager, name string) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.Name = name
+	return t, nil
+}
+
+// PriorityCommand records a change of a task's priority.
+type PriorityCommand struct {
+	TaskID int      `json:"task_id"`
+	Old    Priority `json:"old"`
+	New    Priority `json:"new"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *PriorityCommand) Kind() ActionType { return ActionTypePriority }
+
+// Apply implements Command.
+func (c *PriorityCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *PriorityCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	if err == nil && c.Old != c.New {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *PriorityCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("priority %s %s → %s", tm.describeTask(c.TaskID), c.Old, c.New)
+}
+
+// set changes the priority of the task.
+func (c *PriorityCommand) set(tm *TaskManager, priority Priority) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.Priority = priority
+	tm.sortTasks()
+	return t, nil
+}
+
+// DependencyCommand records a change of the tasks blocking a task.
+type DependencyCommand struct {
+	TaskID int   `json:"task_id"`
+	Old    []int `json:"old,omitempty"`
+	New    []int `json:"new,omitempty"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *DependencyCommand) Kind() ActionType { return ActionTypeDependency }
+
+// Apply implements Command.
+func (c *DependencyCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *DependencyCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	// Clearing the blockers of a task without any records no change
+	if err == nil && (len(c.Old) > 0 || len(c.New) > 0) {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *DependencyCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("blockers %s %s → %s", tm.describeTask(c.TaskID), orNone(FormatIDs(c.Old)), orNone(FormatIDs(c.New)))
+}
+
+// set replaces the blockers of the task.
+func (c *DependencyCommand) set(tm *TaskManager, blockers []int) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.BlockedBy = blockers
+	return t, nil
+}
+
+// RecurrenceCommand records a change of a task's recurrence rule.
+type RecurrenceCommand struct {
+	TaskID int         `json:"task_id"`
+	Old    *Recurrence `json:"old,omitempty"`
+	New    *Recurrence `json:"new,omitempty"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *RecurrenceCommand) Kind() ActionType { return ActionTypeRecurrence }
+
+// Apply implements Command.
+func (c *RecurrenceCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *RecurrenceCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	if err == nil {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *RecurrenceCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("recurrence %s %s → %s", tm.describeTask(c.TaskID), formatRecurrence(c.Old), formatRecurrence(c.New))
+}
+
+// set changes or removes the recurrence rule of the task.
+func (c *RecurrenceCommand) set(tm *TaskManager, recurrence *Recurrence) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.Recurrence = recurrence
+	return t, nil
+}
+
+// NotesCommand records a change of a task's notes.
+type NotesCommand struct {
+	TaskID int    `json:"task_id"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *NotesCommand) Kind() ActionType { return ActionTypeNotes }
+
+// Apply implements Command.
+func (c *NotesCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *NotesCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	if err == nil && c.Old != c.New {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *NotesCommand) Describe(tm *TaskManager) string {
+	return "notes " + tm.describeTask(c.TaskID)
+}
+
+// set replaces the notes of the task.
+func (c *NotesCommand) set(tm *TaskManager, notes string) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.Notes = notes
+	return t, nil
+}
+
+// TagsCommand records a change of a task's tags.
+type TagsCommand struct {
+	TaskID int      `json:"task_id"`
+	Old    []string `json:"old,omitempty"`
+	New    []string `json:"new,omitempty"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *TagsCommand) Kind() ActionType { return ActionTypeTags }
+
+// Apply implements Command.
+func (c *TagsCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *TagsCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	if err == nil && FormatTags(c.Old) != FormatTags(c.New) {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *TagsCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("tags %s %s → %s", tm.describeTask(c.TaskID), orNone(FormatTags(c.Old)), orNone(FormatTags(c.New)))
+}
+
+// set replaces the tags of the task.
+func (c *TagsCommand) set(tm *TaskManager, tags []string) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.Tags = tags
+	return t, nil
+}
+
+// ProjectCommand records a change of a task's project.
+type ProjectCommand struct {
+	TaskID int    `json:"task_id"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *ProjectCommand) Kind() ActionType { return ActionTypeProject }
+
+// Apply implements Command.
+func (c *ProjectCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *ProjectCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	if err == nil && c.Old != c.New {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *ProjectCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("project %s %s → %s", tm.describeTask(c.TaskID), orNone(FormatProject(c.Old)), orNone(FormatProject(c.New)))
+}
+
+// set changes or clears the project of the task.
+func (c *ProjectCommand) set(tm *TaskManager, project string) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.Project = project
+	return t, nil
+}
+
+// DueCommand records a change of a task's due date.
+type DueCommand struct {
+	TaskID int        `json:"task_id"`
+	Old    *time.Time `json:"old,omitempty"`
+	New    *time.Time `json:"new,omitempty"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *DueCommand) Kind() ActionType { return ActionTypeDue }
+
+// Apply implements Command.
+func (c *DueCommand) Apply(tm *TaskManager) error { return c.redo(c.set(tm, c.New)) }
+
+// Revert implements Command.
+func (c *DueCommand) Revert(tm *TaskManager) error {
+	t, err := c.set(tm, c.Old)
+	if err == nil {
+		c.undo(t, c.Kind())
+	}
+	return err
+}
+
+// Describe implements Command.
+func (c *DueCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("due %s %s → %s", tm.describeTask(c.TaskID), formatDue(c.Old), formatDue(c.New))
+}
+
+// set changes or clears the due date of the task.
+func (c *DueCommand) set(tm *TaskManager, due *time.Time) (*Task, error) {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	t.DueAt = due
+	tm.sortTasks()
+	return t, nil
+}
+
+// StatusCommand records a change of a task's workflow status, including
+// cancelling and reopening cancelled tasks.
+type StatusCommand struct {
+	TaskID int    `json:"task_id"`
+	Old    Status `json:"old,omitempty"`
+	New    Status `json:"new,omitempty"`
+	// At is when the task entered the new status, PreviousAt when it last
+	// entered it before, zero if never.
+	At         time.Time `json:"at,omitzero"`
+	PreviousAt time.Time `json:"previous_at,omitzero"`
+	undoneChange
+}
+
+// Kind implements Command.
+func (c *StatusCommand) Kind() ActionType { return ActionTypeStatus }
+
+// Apply implements Command.
+func (c *StatusCommand) Apply(tm *TaskManager) error {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return err
+	}
+	if err := tm.applyStatus(t, c.New); err != nil {
+		return err
+	}
+	if !c.At.IsZero() {
+		t.markStatus(c.New, c.At)
+	}
+	return c.redo(t, nil)
+}
+
+// Revert implements Command.
+func (c *StatusCommand) Revert(tm *TaskManager) error {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return err
+	}
+	if err := tm.applyStatus(t, c.Old); err != nil {
+		return err
+	}
+	if c.PreviousAt.IsZero() {
+		delete(t.StatusAt, c.New)
+	} else {
+		t.markStatus(c.New, c.PreviousAt)
+	}
+	if c.Old != c.New {
+		c.undo(t, c.Kind())
+	}
+	return nil
+}
+
+// Describe implements Command.
+func (c *StatusCommand) Describe(tm *TaskManager) string {
+	return fmt.Sprintf("status %s %s → %s", tm.describeTask(c.TaskID), orNone(strings.ToLower(c.Old.Label())), orNone(strings.ToLower(c.New.Label())))
+}
+
+// ReorderCommand records a change of the manual order, see MoveTask.
+type ReorderCommand struct {
+	// TaskID is the task that was moved.
+	TaskID int `json:"task_id"`
+	// Old and New map task IDs to their positions.
+	Old map[int]int `json:"old"`
+	New map[int]int `json:"new"`
+}
+
+// Kind implements Command.
+func (c *ReorderCommand) Kind() ActionType { return ActionTypeMove }
+
+// Apply implements Command.
+func (c *ReorderCommand) Apply(tm *TaskManager) error { return c.set(tm, c.New) }
+
+// Revert implements Command.
+func (c *ReorderCommand) Revert(tm *TaskManager) error { return c.set(tm, c.Old) }
+
+// Describe implements Command.
+func (c *ReorderCommand) Describe(tm *TaskManager) string {
+	return "moved " + tm.describeTask(c.TaskID)
+}
+
+// set restores positions; tasks deleted since then are skipped.
+func (c *ReorderCommand) set(tm *TaskManager, positions map[int]int) error {
+	for id, position := range positions {
+		if t := tm.FindTaskByID(id); t != nil {
+			t.Position = position
+		}
+	}
+	tm.sortTasks()
+	return nil
+}
+
+// TimerCommand records starting or stopping the timer of a task.
+type TimerCommand struct {
+	TaskID int `json:"task_id"`
+	// Start is when the time entry started.
+	Start time.Time `json:"start"`
+	// End is when the timer was stopped, nil if it was started.
+	End *time.Time `json:"end,omitempty"`
+}
+
+// Kind implements Command.
+func (c *TimerCommand) Kind() ActionType { return ActionTypeTimer }
+
+// Apply implements Command.
+func (c *TimerCommand) Apply(tm *TaskManager) error {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return err
+	}
+	if c.End == nil {
+		t.TimeEntries = append(t.TimeEntries, TimeEntry{Start: c.Start})
+		return nil
+	}
+	i := c.index(t)
+	if i < 0 || t.TimeEntries[i].End != nil {
+		return fmt.Errorf("%w on #%d", ErrTimerStopped, c.TaskID)
+	}
+	end := *c.End
+	t.TimeEntries[i].End = &end
+	return nil
+}
+
+// Revert implements Command.
+func (c *TimerCommand) Revert(tm *TaskManager) error {
+	t, err := tm.findTask(c.TaskID)
+	if err != nil {
+		return err
+	}
+	i := c.index(t)
+	if i < 0 {
+		return fmt.Errorf("time entry of #%d not found", c.TaskID)
+	}
+	if c.End != nil {
+		t.TimeEntries[i].End = nil
+	} else {
+		t.TimeEntries = slices.Delete(t.TimeEntries, i, i+1)
+	}
+	return nil
+}
+
+// Describe implements Command.
+func (c *TimerCommand) Describe(tm *TaskManager) string {
+	if c.End == nil {
+		return "started timer " + tm.describeTask(c.TaskID)
+	}
+	return fmt.Sprintf("stopped timer %s after %s", tm.describeTask(c.TaskID), FormatDuration(c.End.Sub(c.Start)))
+}
+
+// index returns the position of the time entry the command started or
+// stopped, -1 if the task has no such entry.
+func (c *TimerCommand) index(t *Task) int {
+	return slices.IndexFunc(t.TimeEntries, func(e TimeEntry) bool { return e.Start.Equal(c.Start) })
+}
+
+// BatchCommand groups commands that are undone and redone together as one
+// step. It either takes effect completely or not at all.
+type BatchCommand struct {
+	Commands Commands `json:"commands"`
+}
+
+// NewBatch groups commands into a single undoable step. A batch of one
+// command is returned as that command.
+func NewBatch(commands ...Command) Command {
+	if len(commands) == 1 {
+		return commands[0]
+	}
+	return &BatchCommand{Commands: commands}
+}
+
+// Kind implements Command.
+func (c *BatchCommand) Kind() ActionType { return ActionTypeBatch }
+
+// Apply implements Command, applying the commands in order.
+func (c *BatchCommand) Apply(tm *TaskManager) error {
+	for i, command := range c.Commands {
+		if err := command.Apply(tm); err != nil {
+			revertCommands(tm, c.Commands[:i])
+			return err
+		}
+	}
+	return nil
+}
+
+// Revert implements Command, reverting the commands in reverse order.
+func (c *BatchCommand) Revert(tm *TaskManager) error {
+	for i := len(c.Commands) - 1; i >= 0; i-- {
+		if err := c.Commands[i].Revert(tm); err != nil {
+			for _, command := range c.Commands[i+1:] {
+				_ = command.Apply(tm)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe implements Command.
+func (c *BatchCommand) Describe(tm *TaskManager) string {
+	const shown = 2
+	var descriptions []string
+	for i, command := range c.Commands {
+		if i == shown {
+			descriptions = append(descriptions, "…")
+			break
+		}
+		descriptions = append(descriptions, command.Describe(tm))
+	}
+	return fmt.Sprintf("%d changes: %s", len(c.Commands), strings.Join(descriptions, "; "))
+}
+
+func (c *BatchCommand) heldTasks() []*Task {
+	var held []*Task
+	for _, command := range c.Commands {
+		if holder, ok := command.(taskHolder); ok {
+			held = append(held, holder.heldTasks()...)
+		}
+	}
+	return held
+}
+
+// revertCommands reverts commands in reverse order, ignoring failures.
+func revertCommands(tm *TaskManager, commands []Command) {
+	for i := len(commands) - 1; i >= 0; i-- {
+		_ = commands[i].Revert(tm)
+	}
+}
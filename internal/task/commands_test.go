@@ -0,0 +1,134 @@
+package task
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCommands(t *testing.T) {
+	t.Run("encode and decode every kind", func(t *testing.T) {
+		weekly := &Recurrence{Unit: RecurrenceWeek, Interval: 1}
+		due := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)
+		commands := Commands{
+			&AddCommand{Task: &Task{ID: 1, Name: "Added"}},
+			&DeleteCommand{Task: &Task{ID: 2, Name: "Deleted", IsDone: true}},
+			&CompleteCommand{TaskID: 3},
+			&UncompleteCommand{TaskID: 4},
+			&EditCommand{TaskID: 5, Old: "Old", New: "New"},
+			&PriorityCommand{TaskID: 6, Old: PriorityLow, New: PriorityHigh},
+			&DependencyCommand{TaskID: 7, Old: nil, New: []int{1, 2}},
+			&RecurrenceCommand{TaskID: 8, Old: nil, New: weekly},
+			&NotesCommand{TaskID: 9, Old: "", New: "notes"},
+			&TagsCommand{TaskID: 10, Old: []string{"a"}, New: []string{"a", "b"}},
+			&ProjectCommand{TaskID: 15, Old: "", New: "website"},
+			&DueCommand{TaskID: 16, Old: nil, New: &due},
+			&StatusCommand{TaskID: 17, Old: StatusTodo, New: StatusInProgress},
+			&TimerCommand{TaskID: 18, Start: due, End: &due},
+			&ReorderCommand{TaskID: 13, Old: map[int]int{13: 0, 14: 0}, New: map[int]int{13: 2, 14: 1}},
+			NewBatch(&CompleteCommand{TaskID: 11}, &CompleteCommand{TaskID: 12}),
+		}
+		if len(commands) != len(commandKinds) {
+			t.Fatalf("expected a command of each of the %d kinds, got %d", len(commandKinds), len(commands))
+		}
+
+		data, err := json.Marshal(commands)
+		if err != nil {
+			t.Fatalf("expected no error encoding commands, got %v", err)
+		}
+		var decoded Commands
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("expected no error decoding commands, got %v", err)
+		}
+		if !reflect.DeepEqual(decoded, commands) {
+			t.Errorf("expected decoded commands to match, got %s", data)
+		}
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		deploy := tm.AddTask("deploy")
+		due := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)
+		tests := []struct {
+			command  Command
+			expected string
+		}{
+			{&CompleteCommand{TaskID: deploy.ID}, `completed #1 "deploy"`},
+			{&PriorityCommand{TaskID: deploy.ID, Old: PriorityLow, New: PriorityHigh}, `priority #1 "deploy" low → high`},
+			{&TagsCommand{TaskID: deploy.ID, New: []string{"ops"}}, `tags #1 "deploy" none → #ops`},
+			{&DueCommand{TaskID: deploy.ID, Old: &due, New: nil}, `due #1 "deploy" 2026-11-03 → none`},
+			{&StatusCommand{TaskID: deploy.ID, New: StatusInProgress}, `status #1 "deploy" none → in progress`},
+			{&DeleteCommand{Task: &Task{ID: 7, Name: "gone"}}, `deleted #7 "gone"`},
+			{&NotesCommand{TaskID: 42}, "notes #42"},
+			{NewBatch(&CompleteCommand{TaskID: 1}, &CompleteCommand{TaskID: 2}, &CompleteCommand{TaskID: 3}),
+				`3 changes: completed #1 "deploy"; completed #2; …`},
+		}
+		for _, tt := range tests {
+			if got := tt.command.Describe(tm); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		}
+	})
+
+	t.Run("batch applies completely or not at all", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		first := tm.AddTask("First")
+		second := tm.AddTask("Second")
+		if err := tm.AddDependency(second.ID, first.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		// Completing the blocked task first fails and reverts the rest
+		batch := NewBatch(
+			&PriorityCommand{TaskID: first.ID, Old: PriorityNone, New: PriorityHigh},
+			&CompleteCommand{TaskID: second.ID},
+		)
+		if err := batch.Apply(tm); err == nil {
+			t.Fatal("expected batch to fail on the blocked task")
+		}
+		if first.Priority != PriorityNone {
+			t.Errorf("expected priority change to be reverted, got %v", first.Priority)
+		}
+		if len(tm.GetDoneTasks()) != 0 {
+			t.Errorf("expected no completed tasks, got %d", len(tm.GetDoneTasks()))
+		}
+	})
+
+	t.Run("failed redo stays on the redo stack", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		um := NewUndoManager(10)
+		blocker := tm.AddTask("Blocker")
+		task := tm.AddTask("Task")
+		tm.CompleteTask(task.ID)
+		um.PushUndo(&CompleteCommand{TaskID: task.ID})
+
+		um.Undo(tm)
+		if err := tm.AddDependency(task.ID, blocker.ID); err != nil {
+			t.Fatal(err)
+		}
+		if um.Redo(tm) == nil {
+			t.Error("expected redo of a blocked completion to fail")
+		}
+		if !um.CanRedo() {
+			t.Error("expected the command to stay on the redo stack")
+		}
+	})
+
+	t.Run("redo restores the task undone", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		um := NewUndoManager(10)
+		task := tm.AddTask("Test Task")
+		deleted := tm.DeleteTask(task.ID)
+		um.PushUndo(&DeleteCommand{Task: deleted})
+
+		um.Undo(tm)
+		if tm.FindTaskByID(task.ID) != task {
+			t.Fatal("expected the deleted task itself to be restored")
+		}
+		um.Redo(tm)
+		if tm.FindTaskByID(task.ID) != nil {
+			t.Error("expected task to be deleted again")
+		}
+	})
+}
@@ -8,6 +8,26 @@ const (
 	ActionTypeUncomplete = "uncomplete"
 	ActionTypeEdit       = "edit"
 	ActionTypePriority   = "priority"
+	ActionTypeDependency = "dependency"
+	ActionTypeRecurrence = "recurrence"
+	ActionTypeNotes      = "notes"
+	ActionTypeTags       = "tags"
+	ActionTypeProject    = "project"
+	ActionTypeDue        = "due"
+	ActionTypeStatus     = "status"
+	ActionTypeMove       = "move"
+	ActionTypeTimer      = "timer"
+	ActionTypeBatch      = "batch"
+)
+
+// Limits on the length of task fields, checked when editing tasks and when
+// loading or saving them
+const (
+	// MaxNameLength is the maximum number of characters in a task name.
+	MaxNameLength = 200
+	// MaxNotesLength is the maximum number of characters in the notes of a
+	// task.
+	MaxNotesLength = 10000
 )
 
 // Default maximum undo stack size
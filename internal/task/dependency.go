@@ -0,0 +1,167 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrTaskNotFound is returned when a task with the given ID does not exist.
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrSelfDependency is returned when a task is linked to itself.
+	ErrSelfDependency = errors.New("task cannot be blocked by itself")
+	// ErrDependencyCycle is returned when a link would make a task transitively block itself.
+	ErrDependencyCycle = errors.New("dependency would create a cycle")
+)
+
+// SetDependencies replaces the list of tasks blocking the task with the given ID.
+// The whole list is rejected if any blocker is unknown or would create a cycle.
+func (tm *TaskManager) SetDependencies(id int, blockerIDs []int) error {
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return ErrTaskNotFound
+	}
+
+	blockers := []int{}
+	seen := make(map[int]bool)
+	for _, blockerID := range blockerIDs {
+		if seen[blockerID] {
+			continue
+		}
+		seen[blockerID] = true
+		if err := tm.checkDependency(id, blockerID); err != nil {
+			return err
+		}
+		blockers = append(blockers, blockerID)
+	}
+
+	if len(blockers) == 0 {
+		if len(t.BlockedBy) > 0 {
+			t.recordChange(ActionTypeDependency, "no longer blocked")
+		}
+		t.BlockedBy = nil
+	} else {
+		t.recordChange(ActionTypeDependency, "blocked by "+FormatIDs(blockers))
+		t.BlockedBy = blockers
+	}
+	return nil
+}
+
+// AddDependency marks the task with the given ID as blocked by another task.
+func (tm *TaskManager) AddDependency(id, blockerID int) error {
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return ErrTaskNotFound
+	}
+	for _, existing := range t.BlockedBy {
+		if existing == blockerID {
+			return nil
+		}
+	}
+	if err := tm.checkDependency(id, blockerID); err != nil {
+		return err
+	}
+	t.BlockedBy = append(t.BlockedBy, blockerID)
+	t.recordChange(ActionTypeDependency, fmt.Sprintf("blocked by #%d", blockerID))
+	return nil
+}
+
+// RemoveDependency removes a blocker from the task with the given ID.
+func (tm *TaskManager) RemoveDependency(id, blockerID int) error {
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return ErrTaskNotFound
+	}
+	for i, existing := range t.BlockedBy {
+		if existing == blockerID {
+			t.BlockedBy = append(t.BlockedBy[:i:i], t.BlockedBy[i+1:]...)
+			t.recordChange(ActionTypeDependency, fmt.Sprintf("no longer blocked by #%d", blockerID))
+			break
+		}
+	}
+	if len(t.BlockedBy) == 0 {
+		t.BlockedBy = nil
+	}
+	return nil
+}
+
+// Blockers returns the unfinished tasks that block the task with the given ID.
+// Links to completed or deleted tasks are ignored.
+func (tm *TaskManager) Blockers(id int) []*Task {
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return nil
+	}
+	var blockers []*Task
+	for _, blockerID := range t.BlockedBy {
+		for _, candidate := range tm.tasks {
+			if candidate.ID == blockerID {
+				blockers = append(blockers, candidate)
+				break
+			}
+		}
+	}
+	return blockers
+}
+
+// IsBlocked reports whether the task with the given ID has unfinished blockers.
+func (tm *TaskManager) IsBlocked(id int) bool {
+	return len(tm.Blockers(id)) > 0
+}
+
+// ReadyTasks returns the active tasks that are not blocked by any unfinished task.
+func (tm *TaskManager) ReadyTasks() []*Task {
+	ready := []*Task{}
+	for _, t := range tm.tasks {
+		if !tm.IsBlocked(t.ID) {
+			ready = append(ready, t)
+		}
+	}
+	return ready
+}
+
+// checkDependency validates that id can be blocked by blockerID.
+func (tm *TaskManager) checkDependency(id, blockerID int) error {
+	if id == blockerID {
+		return ErrSelfDependency
+	}
+	if tm.FindTaskByID(blockerID) == nil {
+		return ErrTaskNotFound
+	}
+	if tm.dependsOn(blockerID, id, make(map[int]bool)) {
+		return ErrDependencyCycle
+	}
+	return nil
+}
+
+// dependsOn reports whether the task with the given ID is transitively blocked by target.
+func (tm *TaskManager) dependsOn(id, target int, visited map[int]bool) bool {
+	if id == target {
+		return true
+	}
+	if visited[id] {
+		return false
+	}
+	visited[id] = true
+
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return false
+	}
+	for _, blockerID := range t.BlockedBy {
+		if tm.dependsOn(blockerID, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatIDs formats task IDs as a comma-separated list like "#3, #7".
+func FormatIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(parts, ", ")
+}
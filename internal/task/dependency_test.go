@@ -0,0 +1,144 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDependencies(t *testing.T) {
+	t.Run("blocked task cannot be completed", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		blocker := tm.AddTask("Write API")
+		blocked := tm.AddTask("Write client")
+
+		if err := tm.AddDependency(blocked.ID, blocker.ID); err != nil {
+			t.Fatalf("expected no error adding dependency, got %v", err)
+		}
+
+		if !tm.IsBlocked(blocked.ID) {
+			t.Error("expected task to be blocked")
+		}
+		if tm.CompleteTask(blocked.ID) != nil {
+			t.Error("expected blocked task to not be completed")
+		}
+
+		tm.CompleteTask(blocker.ID)
+		if tm.IsBlocked(blocked.ID) {
+			t.Error("expected task to be unblocked after blocker is completed")
+		}
+		if tm.CompleteTask(blocked.ID) == nil {
+			t.Error("expected unblocked task to be completed")
+		}
+	})
+
+	t.Run("ready tasks", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		blocker := tm.AddTask("Blocker")
+		blocked := tm.AddTask("Blocked")
+		if err := tm.AddDependency(blocked.ID, blocker.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		ready := tm.ReadyTasks()
+		if len(ready) != 1 || ready[0].ID != blocker.ID {
+			t.Errorf("expected only the blocker to be ready, got %v", ready)
+		}
+	})
+
+	t.Run("reject self dependency", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Task")
+
+		if err := tm.AddDependency(task.ID, task.ID); !errors.Is(err, ErrSelfDependency) {
+			t.Errorf("expected ErrSelfDependency, got %v", err)
+		}
+	})
+
+	t.Run("reject unknown blocker", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Task")
+
+		if err := tm.AddDependency(task.ID, 42); !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("expected ErrTaskNotFound, got %v", err)
+		}
+	})
+
+	t.Run("detect cycles", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		a := tm.AddTask("A")
+		b := tm.AddTask("B")
+		c := tm.AddTask("C")
+
+		if err := tm.AddDependency(a.ID, b.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := tm.AddDependency(b.ID, c.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tm.AddDependency(c.ID, a.ID); !errors.Is(err, ErrDependencyCycle) {
+			t.Errorf("expected ErrDependencyCycle, got %v", err)
+		}
+		if err := tm.SetDependencies(c.ID, []int{a.ID}); !errors.Is(err, ErrDependencyCycle) {
+			t.Errorf("expected ErrDependencyCycle from SetDependencies, got %v", err)
+		}
+		if len(c.BlockedBy) != 0 {
+			t.Errorf("expected rejected links to leave task unchanged, got %v", c.BlockedBy)
+		}
+	})
+
+	t.Run("set and clear dependencies", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		a := tm.AddTask("A")
+		b := tm.AddTask("B")
+		c := tm.AddTask("C")
+
+		if err := tm.SetDependencies(a.ID, []int{b.ID, c.ID, b.ID}); err != nil {
+			t.Fatal(err)
+		}
+		if len(a.BlockedBy) != 2 {
+			t.Errorf("expected duplicates to be dropped, got %v", a.BlockedBy)
+		}
+
+		if err := tm.RemoveDependency(a.ID, b.ID); err != nil {
+			t.Fatal(err)
+		}
+		if len(tm.Blockers(a.ID)) != 1 {
+			t.Errorf("expected 1 blocker after removal, got %d", len(tm.Blockers(a.ID)))
+		}
+
+		if err := tm.SetDependencies(a.ID, nil); err != nil {
+			t.Fatal(err)
+		}
+		if a.BlockedBy != nil {
+			t.Errorf("expected blockers to be cleared, got %v", a.BlockedBy)
+		}
+	})
+
+	t.Run("undo dependency change", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		um := NewUndoManager(10)
+		a := tm.AddTask("A")
+		b := tm.AddTask("B")
+
+		oldBlockers := a.BlockedBy
+		if err := tm.SetDependencies(a.ID, []int{b.ID}); err != nil {
+			t.Fatal(err)
+		}
+		um.PushUndo(&DependencyCommand{
+			TaskID: a.ID,
+			Old:    oldBlockers,
+			New:    a.BlockedBy,
+		})
+
+		um.Undo(tm)
+		if tm.IsBlocked(a.ID) {
+			t.Error("expected task to be unblocked after undo")
+		}
+
+		um.Redo(tm)
+		if !tm.IsBlocked(a.ID) {
+			t.Error("expected task to be blocked again after redo")
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GroupMode is a way of grouping the task list under headers.
+type GroupMode string
+
+// Group modes
+const (
+	// GroupNone lists tasks without headers.
+	GroupNone GroupMode = "none"
+	// GroupPriority groups tasks by priority, highest first.
+	GroupPriority GroupMode = "priority"
+	// GroupProject groups tasks by project, tasks without one last.
+	GroupProject GroupMode = "project"
+	// GroupTag groups tasks by their first tag, untagged tasks last.
+	GroupTag GroupMode = "tag"
+	// GroupDue groups tasks by when they are due: overdue, today, tomorrow,
+	// this week, later, or not at all.
+	GroupDue GroupMode = "due"
+)
+
+// GroupModes lists the group modes in the order they are cycled through.
+var GroupModes = []GroupMode{GroupNone, GroupPriority, GroupProject, GroupTag, GroupDue}
+
+// ParseGroupMode parses the name of a group mode. An empty name means no
+// grouping.
+func ParseGroupMode(name string) (GroupMode, error) {
+	if name == "" {
+		return GroupNone, nil
+	}
+	for _, mode := range GroupModes {
+		if strings.EqualFold(name, string(mode)) {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown group mode %q", name)
+}
+
+// TaskGroup is a named group of tasks.
+type TaskGroup struct {
+	Name  string
+	Tasks []*Task
+}
+
+// groupKey identifies the group of a task. Groups are listed by rank, then
+// by name.
+type groupKey struct {
+	rank int
+	name string
+}
+
+// GroupTasks splits tasks into groups, keeping the order of the tasks within
+// each group. Due dates are bucketed relative to now. Without grouping all
+// tasks are returned in a single unnamed group.
+func GroupTasks(tasks []*Task, mode GroupMode, now time.Time) []TaskGroup {
+	if mode == GroupNone || mode == "" {
+		return []TaskGroup{{Tasks: tasks}}
+	}
+
+	var keys []groupKey
+	groups := make(map[groupKey][]*Task)
+	for _, t := range tasks {
+		key := taskGroupKey(t, mode, now)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].rank != keys[j].rank {
+			return keys[i].rank < keys[j].rank
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	result := make([]TaskGroup, len(keys))
+	for i, key := range keys {
+		result[i] = TaskGroup{Name: key.name, Tasks: groups[key]}
+	}
+	return result
+}
+
+// taskGroupKey returns the group a task belongs to.
+func taskGroupKey(t *Task, mode GroupMode, now time.Time) groupKey {
+	switch mode {
+	case GroupPriority:
+		name := "No priority"
+		if t.Priority != PriorityNone {
+			name = strings.ToUpper(t.Priority.String()[:1]) + t.Priority.String()[1:] + " priority"
+		}
+		return groupKey{rank: -int(t.Priority), name: name}
+	case GroupProject:
+		if t.Project == "" {
+			return groupKey{rank: 1, name: "No project"}
+		}
+		return groupKey{name: FormatProject(t.Project)}
+	case GroupTag:
+		if len(t.Tags) == 0 {
+			return groupKey{rank: 1, name: "Untagged"}
+		}
+		return groupKey{name: "#" + t.Tags[0]}
+	case GroupDue:
+		return dueBucket(t, now)
+	}
+	return groupKey{}
+}
+
+// dueBucket returns the due date group of a task.
+func dueBucket(t *Task, now time.Time) groupKey {
+	if t.DueAt == nil {
+		return groupKey{rank: 5, name: "No due date"}
+	}
+	today := StartOfDay(now)
+	switch due := *t.DueAt; {
+	case due.Before(today):
+		return groupKey{rank: 0, name: "Overdue"}
+	case due.Before(today.AddDate(0, 0, 1)):
+		return groupKey{rank: 1, name: "Today"}
+	case due.Before(today.AddDate(0, 0, 2)):
+		return groupKey{rank: 2, name: "Tomorrow"}
+	case due.Before(today.AddDate(0, 0, 7)):
+		return groupKey{rank: 3, name: "This week"}
+	default:
+		return groupKey{rank: 4, name: "Later"}
+	}
+}
@@ -0,0 +1,93 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupTasks(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC) // a Wednesday
+	at := func(days int) *time.Time {
+		due := StartOfDay(now).AddDate(0, 0, days).Add(9 * time.Hour)
+		return &due
+	}
+	tasks := []*Task{
+		{ID: 1, Name: "ship", Priority: PriorityHigh, Project: "website", Tags: []string{"ops"}, DueAt: at(0)},
+		{ID: 2, Name: "plan", Priority: PriorityLow, DueAt: at(10)},
+		{ID: 3, Name: "fix", Priority: PriorityHigh, Project: "api", Tags: []string{"backend", "ops"}, DueAt: at(-1)},
+		{ID: 4, Name: "call", Project: "website", DueAt: at(3)},
+		{ID: 5, Name: "read", DueAt: at(1)},
+		{ID: 6, Name: "rest"},
+	}
+
+	tests := []struct {
+		mode     GroupMode
+		expected map[string][]string
+		order    []string
+	}{
+		{GroupPriority, map[string][]string{"High priority": {"ship", "fix"}, "Low priority": {"plan"}, "No priority": {"call", "read", "rest"}},
+			[]string{"High priority", "Low priority", "No priority"}},
+		{GroupProject, map[string][]string{"+api": {"fix"}, "+website": {"ship", "call"}, "No project": {"plan", "read", "rest"}},
+			[]string{"+api", "+website", "No project"}},
+		{GroupTag, map[string][]string{"#backend": {"fix"}, "#ops": {"ship"}, "Untagged": {"plan", "call", "read", "rest"}},
+			[]string{"#backend", "#ops", "Untagged"}},
+		{GroupDue, map[string][]string{"Overdue": {"fix"}, "Today": {"ship"}, "Tomorrow": {"read"}, "This week": {"call"}, "Later": {"plan"}, "No due date": {"rest"}},
+			[]string{"Overdue", "Today", "Tomorrow", "This week", "Later", "No due date"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			groups := GroupTasks(tasks, tt.mode, now)
+			if len(groups) != len(tt.order) {
+				t.Fatalf("expected %d groups, got %d", len(tt.order), len(groups))
+			}
+			for i, group := range groups {
+				if group.Name != tt.order[i] {
+					t.Errorf("expected group %d to be %s, got %s", i, tt.order[i], group.Name)
+				}
+				if got := names(group.Tasks); !equalNames(got, tt.expected[group.Name]) {
+					t.Errorf("expected %s to hold %v, got %v", group.Name, tt.expected[group.Name], got)
+				}
+			}
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		groups := GroupTasks(tasks, GroupNone, now)
+		if len(groups) != 1 || groups[0].Name != "" || len(groups[0].Tasks) != len(tasks) {
+			t.Errorf("expected a single unnamed group, got %+v", groups)
+		}
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		if mode, err := ParseGroupMode("Tag"); err != nil || mode != GroupTag {
+			t.Errorf("expected tag group mode, got %q (%v)", mode, err)
+		}
+		if mode, err := ParseGroupMode(""); err != nil || mode != GroupNone {
+			t.Errorf("expected no grouping as default, got %q (%v)", mode, err)
+		}
+		if _, err := ParseGroupMode("random"); err == nil {
+			t.Error("expected error for unknown group mode")
+		}
+	})
+}
+
+func TestSetTaskProject(t *testing.T) {
+	tm := NewTaskManager(nil, nil, 0)
+	task := tm.AddTask("ship")
+
+	if _, err := tm.SetTaskProject(task.ID, "+Website"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if task.Project != "website" {
+		t.Errorf("expected project website, got %q", task.Project)
+	}
+	if _, err := tm.SetTaskProject(task.ID, "web site"); err == nil {
+		t.Error("expected error for invalid project name")
+	}
+	if _, err := tm.SetTaskProject(task.ID, ""); err != nil || task.Project != "" {
+		t.Errorf("expected project to be cleared, got %q (%v)", task.Project, err)
+	}
+	if _, err := tm.SetTaskProject(99, "api"); err != ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
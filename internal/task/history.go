@@ -0,0 +1,58 @@
+package task
+
+import "time"
+
+// MaxHistoryEntries is the maximum number of changes kept per task.
+const MaxHistoryEntries = 50
+
+// Change records a single modification of a task.
+type Change struct {
+	At      time.Time  `json:"at"`
+	Type    ActionType `json:"type"`
+	Summary string     `json:"summary"`
+}
+
+// recordChange appends a change to the task's history, dropping the oldest
+// entries beyond MaxHistoryEntries.
+func (t *Task) recordChange(actionType ActionType, summary string) {
+	t.History = append(t.History, Change{
+		At:      time.Now(),
+		Type:    actionType,
+		Summary: summary,
+	})
+	if len(t.History) > MaxHistoryEntries {
+		t.History = t.History[len(t.History)-MaxHistoryEntries:]
+	}
+}
+
+// LastChange returns the most recent change of the given type, or nil if the
+// task has no such change in its history.
+func (t *Task) LastChange(actionType ActionType) *Change {
+	for i := len(t.History) - 1; i >= 0; i-- {
+		if t.History[i].Type == actionType {
+			return &t.History[i]
+		}
+	}
+	return nil
+}
+
+// takeChange removes the most recent change of the given type from the task's
+// history and returns it, or nil if the task has no such change.
+func (t *Task) takeChange(actionType ActionType) *Change {
+	for i := len(t.History) - 1; i >= 0; i-- {
+		if t.History[i].Type == actionType {
+			change := t.History[i]
+			t.History = append(t.History[:i:i], t.History[i+1:]...)
+			return &change
+		}
+	}
+	return nil
+}
+
+// restoreChange puts a change taken with takeChange back into the history.
+func (t *Task) restoreChange(change Change) {
+	t.History = append(t.History, change)
+	if len(t.History) > MaxHistoryEntries {
+		t.History = t.History[len(t.History)-MaxHistoryEntries:]
+	}
+}
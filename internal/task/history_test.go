@@ -0,0 +1,59 @@
+package task
+
+import "testing"
+
+func TestTaskHistory(t *testing.T) {
+	t.Run("mutations are recorded", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Deploy")
+		tm.UpdateTaskName(task.ID, "Deploy to production")
+		tm.SetTaskPriority(task.ID, PriorityHigh)
+		tm.CompleteTask(task.ID)
+
+		expected := []ActionType{ActionTypeAdd, ActionTypeEdit, ActionTypePriority, ActionTypeComplete}
+		if len(task.History) != len(expected) {
+			t.Fatalf("expected %d history entries, got %d", len(expected), len(task.History))
+		}
+		for i, actionType := range expected {
+			if task.History[i].Type != actionType {
+				t.Errorf("expected entry %d to be %s, got %s", i, actionType, task.History[i].Type)
+			}
+		}
+		if task.History[1].Summary != `renamed from "Deploy"` {
+			t.Errorf("unexpected rename summary %q", task.History[1].Summary)
+		}
+		if task.LastChange(ActionTypeComplete) == nil {
+			t.Error("expected to find the completion in the history")
+		}
+	})
+
+	t.Run("unchanged values are not recorded", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Deploy")
+		tm.UpdateTaskName(task.ID, "Deploy")
+		tm.SetTaskPriority(task.ID, PriorityNone)
+
+		if len(task.History) != 1 {
+			t.Errorf("expected only the creation to be recorded, got %d entries", len(task.History))
+		}
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Task")
+		for i := 0; i < MaxHistoryEntries+10; i++ {
+			priority := PriorityHigh
+			if i%2 == 0 {
+				priority = PriorityLow
+			}
+			tm.SetTaskPriority(task.ID, priority)
+		}
+
+		if len(task.History) != MaxHistoryEntries {
+			t.Errorf("expected %d history entries, got %d", MaxHistoryEntries, len(task.History))
+		}
+		if task.LastChange(ActionTypeAdd) != nil {
+			t.Error("expected the oldest entries to be dropped")
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package task
+
+import "strings"
+
+// NormalizeProject lowercases a project name and strips a leading '+'.
+func NormalizeProject(project string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(project), "+"))
+}
+
+// SetTaskProject moves the task with the given ID to a project. Project names
+// follow the same rules as tags; an empty name removes the task from its
+// project.
+func (tm *TaskManager) SetTaskProject(id int, project string) (*Task, error) {
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	project = NormalizeProject(project)
+	if project != "" {
+		if err := ValidateTag(project); err != nil {
+			return nil, err
+		}
+	}
+	if project == t.Project {
+		return t, nil
+	}
+
+	if project == "" {
+		t.recordChange(ActionTypeProject, "project cleared")
+	} else {
+		t.recordChange(ActionTypeProject, "moved to +"+project)
+	}
+	t.Project = project
+	return t, nil
+}
+
+// FormatProject formats a project name like "+website", or returns an empty
+// string for no project.
+func FormatProject(project string) string {
+	if project == "" {
+		return ""
+	}
+	return "+" + project
+}
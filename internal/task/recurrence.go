@@ -0,0 +1,341 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceUnit is the calendar unit a recurrence interval is measured in.
+type RecurrenceUnit string
+
+const (
+	// RecurrenceDay repeats every N days.
+	RecurrenceDay RecurrenceUnit = "day"
+	// RecurrenceWeek repeats every N weeks, optionally on specific weekdays.
+	RecurrenceWeek RecurrenceUnit = "week"
+	// RecurrenceMonth repeats every N months on the same day of the month.
+	RecurrenceMonth RecurrenceUnit = "month"
+)
+
+// Recurrence describes how a task repeats once it is completed.
+type Recurrence struct {
+	// Unit is the calendar unit of the interval.
+	Unit RecurrenceUnit `json:"unit"`
+	// Interval is the number of units between occurrences.
+	Interval int `json:"interval"`
+	// Weekdays restricts weekly recurrences to specific days of the week.
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+	// AfterCompletion schedules the next occurrence relative to the completion
+	// date instead of the previous due date.
+	AfterCompletion bool `json:"after_completion,omitempty"`
+	// DayOfMonth is the day of the month a fixed monthly schedule falls on,
+	// so that an occurrence moved to the end of a shorter month does not pull
+	// the later ones with it. Zero uses the day of the previous due date.
+	DayOfMonth int `json:"day_of_month,omitempty"`
+}
+
+// weekdayNames maps short weekday names to weekdays.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseRecurrence parses a recurrence rule such as "daily", "2w", "mon,fri",
+// "weekdays" or "1m". Weekly rules take days of the week after an "@", e.g.
+// "2w@mon,fri", and monthly ones a day of the month, e.g. "monthly@31". A
+// trailing "!" schedules occurrences after completion instead of on a fixed
+// schedule, e.g. "3d!".
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	if rule == "" {
+		return nil, errors.New("recurrence rule cannot be empty")
+	}
+
+	r := &Recurrence{Interval: 1}
+	if strings.HasSuffix(rule, "!") {
+		r.AfterCompletion = true
+		rule = strings.TrimSuffix(rule, "!")
+	}
+
+	if every, on, ok := strings.Cut(rule, "@"); ok {
+		return parseRecurrenceOn(every, on, r.AfterCompletion)
+	}
+
+	switch rule {
+	case "daily", "day":
+		r.Unit = RecurrenceDay
+		return r, nil
+	case "weekly", "week":
+		r.Unit = RecurrenceWeek
+		return r, nil
+	case "monthly", "month":
+		r.Unit = RecurrenceMonth
+		return r, nil
+	case "weekdays":
+		r.Unit = RecurrenceWeek
+		r.Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		return r, nil
+	}
+
+	// Weekday lists like "mon,wed,fri"
+	if _, ok := weekdayNames[strings.SplitN(rule, ",", 2)[0]]; ok {
+		r.Unit = RecurrenceWeek
+		for _, name := range strings.Split(rule, ",") {
+			day, ok := weekdayNames[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown weekday %q", name)
+			}
+			r.Weekdays = append(r.Weekdays, day)
+		}
+		return r, r.Validate()
+	}
+
+	// Intervals like "3d", "2weeks" or "1m"
+	digits := strings.IndexFunc(rule, func(c rune) bool { return c < '0' || c > '9' })
+	if digits <= 0 {
+		return nil, fmt.Errorf("invalid recurrence rule %q", rule)
+	}
+	interval, err := strconv.Atoi(rule[:digits])
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence interval: %w", err)
+	}
+	r.Interval = interval
+
+	switch rule[digits:] {
+	case "d", "day", "days":
+		r.Unit = RecurrenceDay
+	case "w", "week", "weeks":
+		r.Unit = RecurrenceWeek
+	case "m", "month", "months":
+		r.Unit = RecurrenceMonth
+	default:
+		return nil, fmt.Errorf("unknown recurrence unit %q", rule[digits:])
+	}
+	return r, r.Validate()
+}
+
+// parseRecurrenceOn parses a rule such as "2w@mon,fri" or "1m@31" from the
+// interval before the "@" and the days after it.
+func parseRecurrenceOn(every, on string, afterCompletion bool) (*Recurrence, error) {
+	r, err := ParseRecurrence(every)
+	if err != nil {
+		return nil, err
+	}
+	if r.AfterCompletion || len(r.Weekdays) > 0 {
+		return nil, fmt.Errorf("invalid recurrence rule %q", every+"@"+on)
+	}
+	r.AfterCompletion = afterCompletion
+
+	switch r.Unit {
+	case RecurrenceWeek:
+		days, err := ParseRecurrence(on)
+		if err != nil || len(days.Weekdays) == 0 || days.AfterCompletion {
+			return nil, fmt.Errorf("invalid days of the week %q", on)
+		}
+		r.Weekdays = days.Weekdays
+	case RecurrenceMonth:
+		day, err := strconv.Atoi(on)
+		if err != nil || day < 1 {
+			return nil, fmt.Errorf("invalid day of the month %q", on)
+		}
+		r.DayOfMonth = day
+	default:
+		return nil, errors.New("only weekly and monthly recurrences can be on specific days")
+	}
+	return r, r.Validate()
+}
+
+// Validate checks that the recurrence rule is well-formed.
+func (r *Recurrence) Validate() error {
+	switch r.Unit {
+	case RecurrenceDay, RecurrenceWeek, RecurrenceMonth:
+	default:
+		return fmt.Errorf("invalid recurrence unit %q", r.Unit)
+	}
+	if r.Interval < 1 {
+		return errors.New("recurrence interval must be at least 1")
+	}
+	if len(r.Weekdays) > 0 && r.Unit != RecurrenceWeek {
+		return errors.New("weekdays can only be used with weekly recurrences")
+	}
+	for _, day := range r.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return errors.New("invalid weekday in recurrence")
+		}
+	}
+	if r.DayOfMonth != 0 && r.Unit != RecurrenceMonth {
+		return errors.New("a day of the month can only be used with monthly recurrences")
+	}
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+		return errors.New("invalid day of the month in recurrence")
+	}
+	if r.DayOfMonth != 0 && r.AfterCompletion {
+		return errors.New("a day of the month cannot be used with recurrences after completion")
+	}
+	return nil
+}
+
+// String returns a human-readable description such as "every 2 weeks on mon, fri".
+func (r *Recurrence) String() string {
+	var sb strings.Builder
+	sb.WriteString("every ")
+	if r.Interval > 1 {
+		sb.WriteString(fmt.Sprintf("%d %ss", r.Interval, r.Unit))
+	} else {
+		sb.WriteString(string(r.Unit))
+	}
+	if len(r.Weekdays) > 0 {
+		names := make([]string, len(r.Weekdays))
+		for i, day := range r.Weekdays {
+			names[i] = strings.ToLower(day.String()[:3])
+		}
+		sb.WriteString(" on " + strings.Join(names, ", "))
+	}
+	if r.DayOfMonth != 0 {
+		sb.WriteString(fmt.Sprintf(" on day %d", r.DayOfMonth))
+	}
+	if r.AfterCompletion {
+		sb.WriteString(" after completion")
+	}
+	return sb.String()
+}
+
+// Rule returns the recurrence as a rule that ParseRecurrence reads back as
+// the same recurrence, such as "2w", "mon,fri", "2w@mon,fri", "monthly@31" or
+// "3d!".
+func (r *Recurrence) Rule() string {
+	var rule string
+	switch {
+	case r.Interval == 1 && r.Unit == RecurrenceDay:
+		rule = "daily"
+	case r.Interval == 1:
+		rule = string(r.Unit) + "ly"
+	default:
+		rule = fmt.Sprintf("%d%c", r.Interval, r.Unit[0])
+	}
+
+	if len(r.Weekdays) > 0 {
+		names := make([]string, len(r.Weekdays))
+		for i, day := range r.Weekdays {
+			names[i] = strings.ToLower(day.String()[:3])
+		}
+		days := strings.Join(names, ",")
+		if days == "mon,tue,wed,thu,fri" {
+			days = "weekdays"
+		}
+		rule = days
+		if r.Interval > 1 {
+			rule = fmt.Sprintf("%dw@%s", r.Interval, days)
+		}
+	}
+	if r.DayOfMonth != 0 {
+		rule += fmt.Sprintf("@%d", r.DayOfMonth)
+	}
+	if r.AfterCompletion {
+		rule += "!"
+	}
+	return rule
+}
+
+// Next returns the due date of the occurrence following one that was due at
+// due (nil if it had no due date) and completed at completedAt.
+//
+// Fixed schedules advance from the previous due date, skipping occurrences
+// that are already in the past, and monthly ones stay on their day of the
+// month. Rules with AfterCompletion advance from the completion date.
+func (r *Recurrence) Next(due *time.Time, completedAt time.Time) time.Time {
+	if r.AfterCompletion || due == nil {
+		return r.advance(StartOfDay(completedAt), 0)
+	}
+
+	day := r.DayOfMonth
+	if day == 0 {
+		day = due.Day()
+	}
+	next := r.advance(*due, day)
+	for !next.After(completedAt) {
+		next = r.advance(next, day)
+	}
+	return next
+}
+
+// anchored returns the recurrence of the occurrence following one due at due,
+// with a fixed monthly schedule kept on the day of the month of due.
+func (r *Recurrence) anchored(due *time.Time) *Recurrence {
+	next := *r
+	if next.Unit == RecurrenceMonth && !next.AfterCompletion && next.DayOfMonth == 0 && due != nil {
+		next.DayOfMonth = due.Day()
+	}
+	return &next
+}
+
+// advance returns the first occurrence strictly after from. Monthly
+// occurrences fall on day, or on the day of from when day is zero.
+func (r *Recurrence) advance(from time.Time, day int) time.Time {
+	switch r.Unit {
+	case RecurrenceWeek:
+		if len(r.Weekdays) == 0 {
+			return from.AddDate(0, 0, 7*r.Interval)
+		}
+		for offset := 1; offset <= 7; offset++ {
+			candidate := from.AddDate(0, 0, offset)
+			if !r.onWeekday(candidate.Weekday()) {
+				continue
+			}
+			// Skip the weeks in between when wrapping into the next week
+			if mondayIndex(candidate.Weekday()) <= mondayIndex(from.Weekday()) {
+				candidate = candidate.AddDate(0, 0, 7*(r.Interval-1))
+			}
+			return candidate
+		}
+		return from.AddDate(0, 0, 7*r.Interval)
+	case RecurrenceMonth:
+		if day == 0 {
+			day = from.Day()
+		}
+		return addMonths(from, r.Interval, day)
+	default:
+		return from.AddDate(0, 0, r.Interval)
+	}
+}
+
+// onWeekday reports whether the recurrence includes the given weekday.
+func (r *Recurrence) onWeekday(day time.Weekday) bool {
+	for _, d := range r.Weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// mondayIndex returns the position of the weekday in a week starting on Monday.
+func mondayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// addMonths adds months to t and moves to the given day of the month, clamped
+// to the end of the target month.
+func addMonths(t time.Time, months, day int) time.Time {
+	year, month, _ := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// StartOfDay returns midnight of the day containing t in t's location.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,292 @@
+package task
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"daily", "every day"},
+		{"weekly", "every week"},
+		{"monthly", "every month"},
+		{"3d", "every 3 days"},
+		{"2weeks", "every 2 weeks"},
+		{"mon,fri", "every week on mon, fri"},
+		{"weekdays", "every week on mon, tue, wed, thu, fri"},
+		{"1m!", "every month after completion"},
+		{"2w@mon,fri", "every 2 weeks on mon, fri"},
+		{"3w@weekdays!", "every 3 weeks on mon, tue, wed, thu, fri after completion"},
+		{"monthly@31", "every month on day 31"},
+		{"2m@15", "every 2 months on day 15"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r, err := ParseRecurrence(test.input)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if r.String() != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, r.String())
+			}
+			// The rule reads back as the same recurrence
+			again, err := ParseRecurrence(r.Rule())
+			if err != nil {
+				t.Fatalf("expected rule %q to parse, got %v", r.Rule(), err)
+			}
+			if again.String() != test.expected {
+				t.Errorf("expected rule %q to read back as %q, got %q", r.Rule(), test.expected, again.String())
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "0d", "3y", "mon,funday", "often",
+		"2d@mon", "2w@15", "1m@mon", "1m@0", "1m@32", "1m@31!", "mon@tue", "2w!@mon", "2w@mon@tue"} {
+		if _, err := ParseRecurrence(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestRecurrenceRule(t *testing.T) {
+	for _, r := range []Recurrence{
+		{Unit: RecurrenceDay, Interval: 1},
+		{Unit: RecurrenceDay, Interval: 3, AfterCompletion: true},
+		{Unit: RecurrenceWeek, Interval: 1},
+		{Unit: RecurrenceWeek, Interval: 2, AfterCompletion: true},
+		{Unit: RecurrenceWeek, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Friday}},
+		{Unit: RecurrenceWeek, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}},
+		{Unit: RecurrenceWeek, Interval: 2, Weekdays: []time.Weekday{time.Monday, time.Friday}},
+		{Unit: RecurrenceWeek, Interval: 3, Weekdays: []time.Weekday{time.Sunday}, AfterCompletion: true},
+		{Unit: RecurrenceMonth, Interval: 1},
+		{Unit: RecurrenceMonth, Interval: 1, AfterCompletion: true},
+		{Unit: RecurrenceMonth, Interval: 1, DayOfMonth: 31},
+		{Unit: RecurrenceMonth, Interval: 6, DayOfMonth: 15},
+	} {
+		t.Run(r.String(), func(t *testing.T) {
+			parsed, err := ParseRecurrence(r.Rule())
+			if err != nil {
+				t.Fatalf("expected rule %q to parse, got %v", r.Rule(), err)
+			}
+			if !reflect.DeepEqual(*parsed, r) {
+				t.Errorf("expected rule %q to read back as %+v, got %+v", r.Rule(), r, *parsed)
+			}
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// Wednesday
+	due := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	t.Run("fixed schedule from due date", func(t *testing.T) {
+		r := &Recurrence{Unit: RecurrenceDay, Interval: 2}
+		next := r.Next(&due, due.Add(time.Hour))
+		if !next.Equal(due.AddDate(0, 0, 2)) {
+			t.Errorf("expected %v, got %v", due.AddDate(0, 0, 2), next)
+		}
+	})
+
+	t.Run("fixed schedule skips missed occurrences", func(t *testing.T) {
+		r := &Recurrence{Unit: RecurrenceWeek, Interval: 1}
+		completed := due.AddDate(0, 0, 15)
+		next := r.Next(&due, completed)
+		if !next.Equal(due.AddDate(0, 0, 21)) {
+			t.Errorf("expected %v, got %v", due.AddDate(0, 0, 21), next)
+		}
+	})
+
+	t.Run("after completion", func(t *testing.T) {
+		r := &Recurrence{Unit: RecurrenceDay, Interval: 3, AfterCompletion: true}
+		completed := time.Date(2026, 10, 20, 15, 30, 0, 0, time.UTC)
+		next := r.Next(&due, completed)
+		expected := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+		if !next.Equal(expected) {
+			t.Errorf("expected %v, got %v", expected, next)
+		}
+	})
+
+	t.Run("specific weekdays", func(t *testing.T) {
+		r := &Recurrence{Unit: RecurrenceWeek, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Friday}}
+		next := r.Next(&due, due)
+		if next.Weekday() != time.Friday || !next.Equal(due.AddDate(0, 0, 2)) {
+			t.Errorf("expected Friday %v, got %v", due.AddDate(0, 0, 2), next)
+		}
+		next = r.Next(&next, next)
+		if next.Weekday() != time.Monday || !next.Equal(due.AddDate(0, 0, 5)) {
+			t.Errorf("expected Monday %v, got %v", due.AddDate(0, 0, 5), next)
+		}
+	})
+
+	t.Run("weekdays every other week", func(t *testing.T) {
+		r := &Recurrence{Unit: RecurrenceWeek, Interval: 2, Weekdays: []time.Weekday{time.Monday}}
+		next := r.Next(&due, due)
+		if !next.Equal(due.AddDate(0, 0, 12)) {
+			t.Errorf("expected %v, got %v", due.AddDate(0, 0, 12), next)
+		}
+	})
+
+	t.Run("monthly clamps to end of month", func(t *testing.T) {
+		r := &Recurrence{Unit: RecurrenceMonth, Interval: 1}
+		jan31 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		next := r.Next(&jan31, jan31)
+		expected := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+		if !next.Equal(expected) {
+			t.Errorf("expected %v, got %v", expected, next)
+		}
+	})
+
+	t.Run("monthly returns to the day of the month", func(t *testing.T) {
+		r := &Recurrence{Unit: RecurrenceMonth, Interval: 1, DayOfMonth: 31}
+		feb28 := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+		next := r.Next(&feb28, feb28)
+		expected := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+		if !next.Equal(expected) {
+			t.Errorf("expected %v, got %v", expected, next)
+		}
+	})
+}
+
+func TestRecurringTaskCompletion(t *testing.T) {
+	t.Run("completion spawns next occurrence", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		standup := tm.AddTask("Standup")
+		tm.SetTaskRecurrence(standup.ID, &Recurrence{Unit: RecurrenceDay, Interval: 1})
+
+		tm.CompleteTask(standup.ID)
+
+		tasks := tm.GetTasks()
+		if len(tasks) != 1 {
+			t.Fatalf("expected next occurrence to be spawned, got %d active tasks", len(tasks))
+		}
+		next := tasks[0]
+		if next.Name != "Standup" || next.Recurrence == nil || next.DueAt == nil {
+			t.Errorf("expected recurring occurrence with due date, got %+v", next)
+		}
+		if standup.Recurrence != nil {
+			t.Error("expected recurrence to move to the next occurrence")
+		}
+		if standup.NextOccurrenceID != next.ID {
+			t.Errorf("expected completed task to reference occurrence %d, got %d", next.ID, standup.NextOccurrenceID)
+		}
+	})
+
+	t.Run("next occurrence keeps the task's fields", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		rent := tm.AddTask("Pay rent")
+		jan31 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+		rent.DueAt = &jan31
+		rent.Priority = PriorityHigh
+		rent.Project = "home"
+		rent.Notes = "IBAN in the contract"
+		tm.SetTaskTags(rent.ID, []string{"finance"})
+		tm.SetTaskRecurrence(rent.ID, &Recurrence{Unit: RecurrenceMonth, Interval: 1})
+
+		tm.CompleteTask(rent.ID)
+		next := tm.GetTasks()[0]
+		if next.Priority != PriorityHigh || next.Project != "home" || next.Notes != rent.Notes ||
+			len(next.Tags) != 1 || next.Tags[0] != "finance" {
+			t.Fatalf("expected the occurrence to keep priority, project, notes and tags, got %+v", next)
+		}
+		next.Tags[0] = "bills"
+		if rent.Tags[0] != "finance" {
+			t.Error("expected the occurrence to have its own tags")
+		}
+
+	})
+
+	t.Run("monthly occurrences keep the day of the month", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		jan31 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+		current := tm.AddTask("Send invoice")
+		current.DueAt = &jan31
+		tm.SetTaskRecurrence(current.ID, &Recurrence{Unit: RecurrenceMonth, Interval: 1})
+		// Each occurrence is completed on its due date
+		var dueDates []string
+		for range 3 {
+			tm.spawnNextOccurrence(current, *current.DueAt)
+			current, _ = tm.findTask(current.NextOccurrenceID)
+			dueDates = append(dueDates, current.DueAt.Format("2006-01-02"))
+		}
+		if got := strings.Join(dueDates, " "); got != "2026-02-28 2026-03-31 2026-04-30" {
+			t.Errorf("expected the due dates to stay on the 31st, got %s", got)
+		}
+	})
+
+	t.Run("undo completion removes spawned occurrence", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		um := NewUndoManager(10)
+		report := tm.AddTask("Weekly report")
+		tm.SetTaskRecurrence(report.ID, &Recurrence{Unit: RecurrenceWeek, Interval: 1})
+
+		tm.CompleteTask(report.ID)
+		um.PushUndo(&CompleteCommand{TaskID: report.ID})
+
+		um.Undo(tm)
+
+		tasks := tm.GetTasks()
+		if len(tasks) != 1 || tasks[0].ID != report.ID {
+			t.Fatalf("expected only the original task to remain active, got %v", tasks)
+		}
+		if report.Recurrence == nil {
+			t.Error("expected recurrence to be restored on the original task")
+		}
+
+		um.Redo(tm)
+		if len(tm.GetTasks()) != 1 || len(tm.GetDoneTasks()) != 1 {
+			t.Errorf("expected redo to complete the task and spawn a new occurrence, got %d active, %d done",
+				len(tm.GetTasks()), len(tm.GetDoneTasks()))
+		}
+	})
+
+	completeAndRename := func(t *testing.T) (*TaskManager, *UndoManager, int) {
+		t.Helper()
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		um := NewUndoManager(10)
+		standup := tm.AddTask("Standup")
+		tm.SetTaskRecurrence(standup.ID, &Recurrence{Unit: RecurrenceDay, Interval: 1})
+		tm.CompleteTask(standup.ID)
+		um.PushUndo(&CompleteCommand{TaskID: standup.ID, CompletedAt: standup.CompletedAt})
+		occurrenceID := standup.NextOccurrenceID
+		tm.UpdateTaskName(occurrenceID, "Daily standup")
+		um.PushUndo(&EditCommand{TaskID: occurrenceID, Old: "Standup", New: "Daily standup"})
+
+		if um.Undo(tm) != nil || um.Undo(tm) != nil {
+			t.Fatal("expected both undos to succeed")
+		}
+		return tm, um, occurrenceID
+	}
+	redoAll := func(t *testing.T, tm *TaskManager, um *UndoManager, occurrenceID int) {
+		t.Helper()
+		if err := um.Redo(tm); err != nil {
+			t.Fatalf("expected the completion to be redone, got %v", err)
+		}
+		if err := um.Redo(tm); err != nil {
+			t.Fatalf("expected the rename of the occurrence to be redone, got %v", err)
+		}
+		tasks := tm.GetTasks()
+		if len(tasks) != 1 || tasks[0].ID != occurrenceID || tasks[0].Name != "Daily standup" {
+			t.Errorf("expected occurrence #%d to come back renamed, got %v", occurrenceID, tasks)
+		}
+		if tasks[0].Recurrence == nil {
+			t.Error("expected the occurrence to carry the recurrence again")
+		}
+	}
+
+	t.Run("redo completion restores the same occurrence", func(t *testing.T) {
+		tm, um, occurrenceID := completeAndRename(t)
+		redoAll(t, tm, um, occurrenceID)
+	})
+
+	t.Run("redo completion restores the same occurrence after reload", func(t *testing.T) {
+		tm, um, occurrenceID := completeAndRename(t)
+		tm, um = reload(t, tm, um)
+		redoAll(t, tm, um, occurrenceID)
+	})
+}
@@ -0,0 +1,176 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortMode is an order in which tasks are listed.
+type SortMode string
+
+// Sort modes
+const (
+	// SortPriority lists tasks by priority, then in manual order, then newest first.
+	SortPriority SortMode = "priority"
+	// SortManual lists tasks in the order they were moved to, newest first otherwise.
+	SortManual SortMode = "manual"
+	// SortCreated lists tasks newest first.
+	SortCreated SortMode = "created"
+	// SortDue lists tasks by due date, tasks without one last.
+	SortDue SortMode = "due"
+	// SortName lists tasks alphabetically.
+	SortName SortMode = "name"
+	// SortID lists tasks by ID, oldest first.
+	SortID SortMode = "id"
+)
+
+// SortModes lists the sort modes in the order they are cycled through.
+var SortModes = []SortMode{SortPriority, SortManual, SortCreated, SortDue, SortName, SortID}
+
+// ParseSortMode parses the name of a sort mode. An empty name is the default
+// priority order.
+func ParseSortMode(name string) (SortMode, error) {
+	if name == "" {
+		return SortPriority, nil
+	}
+	for _, mode := range SortModes {
+		if strings.EqualFold(name, string(mode)) {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort mode %q", name)
+}
+
+// SortMode returns the order tasks are kept in.
+func (tm *TaskManager) SortMode() SortMode {
+	if tm.sortMode == "" {
+		return SortPriority
+	}
+	return tm.sortMode
+}
+
+// SetSortMode changes the order tasks are kept in.
+func (tm *TaskManager) SetSortMode(mode SortMode) {
+	tm.sortMode = mode
+	tm.sortTasks()
+}
+
+// SortReverse reports whether tasks are kept in the reverse of their sort mode.
+func (tm *TaskManager) SortReverse() bool {
+	return tm.sortReverse
+}
+
+// SetSortReverse changes the direction tasks are sorted in.
+func (tm *TaskManager) SetSortReverse(reverse bool) {
+	tm.sortReverse = reverse
+	tm.sortTasks()
+}
+
+// SortTasks sorts the given tasks in the order of the task manager.
+func (tm *TaskManager) SortTasks(tasks []*Task) {
+	sortTasksBy(tasks, tm.SortMode(), tm.sortReverse)
+}
+
+// sortTasksBy sorts tasks in the given order, or its reverse. Tasks without a
+// due date stay last when sorting by due date in either direction.
+func sortTasksBy(tasks []*Task, mode SortMode, reverse bool) {
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if mode == SortDue && (a.DueAt == nil) != (b.DueAt == nil) {
+			return a.DueAt != nil
+		}
+		if reverse {
+			return compareTasks(b, a, mode)
+		}
+		return compareTasks(a, b, mode)
+	})
+}
+
+// compareTasks reports whether a comes before b in the given order. Ties are
+// broken by creation date and ID so the order is stable between sorts.
+func compareTasks(a, b *Task, mode SortMode) bool {
+	switch mode {
+	case SortManual:
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+	case SortDue:
+		if a.DueAt != nil && b.DueAt != nil && !a.DueAt.Equal(*b.DueAt) {
+			return a.DueAt.Before(*b.DueAt)
+		}
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+	case SortName:
+		if an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name); an != bn {
+			return an < bn
+		}
+	case SortID:
+		return a.ID < b.ID
+	case SortCreated:
+	default:
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// MoveTask swaps the manual positions of a task and another task of the same
+// list. The list is numbered in its current order first, so moving a task in
+// any sort mode starts from the order the tasks are shown in. The returned
+// command undoes the move.
+func (tm *TaskManager) MoveTask(id, otherID int) (*ReorderCommand, error) {
+	list := tm.tasks
+	if tm.indexOf(tm.tasks, id) < 0 {
+		list = tm.doneTasks
+	}
+	i, j := tm.indexOf(list, id), tm.indexOf(list, otherID)
+	if i < 0 || j < 0 {
+		return nil, ErrTaskNotFound
+	}
+
+	command := &ReorderCommand{TaskID: id, Old: make(map[int]int), New: make(map[int]int)}
+	for index, t := range list {
+		command.Old[t.ID] = t.Position
+		t.Position = index + 1
+	}
+	list[i].Position, list[j].Position = list[j].Position, list[i].Position
+	for _, t := range list {
+		command.New[t.ID] = t.Position
+	}
+	tm.sortTasks()
+	return command, nil
+}
+
+// indexOf returns the index of the task with the given ID in list, or -1.
+func (tm *TaskManager) indexOf(list []*Task, id int) int {
+	for i, t := range list {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortDoneTasks sorts completed and cancelled tasks by when they were closed,
+// newest first. Tasks closed at an unknown time come last, newest ID first.
+func sortDoneTasks(tasks []*Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i].ClosedAt(), tasks[j].ClosedAt()
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.After(*b)
+		case (a == nil) != (b == nil):
+			return a != nil
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+}
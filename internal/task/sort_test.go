@@ -0,0 +1,144 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func names(tasks []*Task) []string {
+	result := make([]string, len(tasks))
+	for i, t := range tasks {
+		result[i] = t.Name
+	}
+	return result
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSortModes(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	due := base.Add(48 * time.Hour)
+	soon := base.Add(24 * time.Hour)
+	tasks := []*Task{
+		{ID: 1, Name: "banana", CreatedAt: base, Priority: PriorityHigh, Position: 3},
+		{ID: 2, Name: "Apple", CreatedAt: base.Add(time.Hour), Priority: PriorityLow, DueAt: &due, Position: 1},
+		{ID: 3, Name: "cherry", CreatedAt: base.Add(2 * time.Hour), Priority: PriorityLow, DueAt: &soon, Position: 2},
+	}
+
+	tests := []struct {
+		mode     SortMode
+		expected []string
+	}{
+		{SortPriority, []string{"banana", "Apple", "cherry"}},
+		{SortManual, []string{"Apple", "cherry", "banana"}},
+		{SortCreated, []string{"cherry", "Apple", "banana"}},
+		{SortDue, []string{"cherry", "Apple", "banana"}},
+		{SortName, []string{"Apple", "banana", "cherry"}},
+		{SortID, []string{"banana", "Apple", "cherry"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			tm := NewTaskManager(tasks, nil, 3)
+			tm.SetSortMode(tt.mode)
+			if got := names(tm.GetTasks()); !equalNames(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("reverse", func(t *testing.T) {
+		tm := NewTaskManager(tasks, nil, 3)
+		tm.SetSortMode(SortName)
+		tm.SetSortReverse(true)
+		if got, expected := names(tm.GetTasks()), []string{"cherry", "banana", "Apple"}; !equalNames(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+
+		// Tasks without a due date stay last
+		tm.SetSortMode(SortDue)
+		if got, expected := names(tm.GetTasks()), []string{"Apple", "cherry", "banana"}; !equalNames(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		if mode, err := ParseSortMode("Due"); err != nil || mode != SortDue {
+			t.Errorf("expected due sort mode, got %q (%v)", mode, err)
+		}
+		if mode, err := ParseSortMode(""); err != nil || mode != SortPriority {
+			t.Errorf("expected priority as default, got %q (%v)", mode, err)
+		}
+		if _, err := ParseSortMode("random"); err == nil {
+			t.Error("expected error for unknown sort mode")
+		}
+	})
+}
+
+func TestMoveTask(t *testing.T) {
+	t.Run("move and undo", func(t *testing.T) {
+		tm := NewTaskManager(nil, nil, 0)
+		tm.SetSortMode(SortManual)
+		third := tm.AddTask("third")
+		second := tm.AddTask("second")
+		first := tm.AddTask("first")
+		um := NewUndoManager(10)
+
+		command, err := tm.MoveTask(third.ID, second.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		um.PushUndo(command)
+		if got := names(tm.GetTasks()); !equalNames(got, []string{"first", "third", "second"}) {
+			t.Errorf("expected third to move up, got %v", got)
+		}
+
+		um.Undo(tm)
+		if got := names(tm.GetTasks()); !equalNames(got, []string{"first", "second", "third"}) {
+			t.Errorf("expected original order after undo, got %v", got)
+		}
+		um.Redo(tm)
+		if got := names(tm.GetTasks()); !equalNames(got, []string{"first", "third", "second"}) {
+			t.Errorf("expected move to be redone, got %v", got)
+		}
+		if first.Position != 1 {
+			t.Errorf("expected list to be numbered, got position %d", first.Position)
+		}
+	})
+
+	t.Run("starts from the shown order", func(t *testing.T) {
+		tm := NewTaskManager(nil, nil, 0)
+		tm.AddTask("b")
+		c := tm.AddTask("c")
+		a := tm.AddTask("a")
+		tm.SetSortMode(SortName)
+
+		if _, err := tm.MoveTask(c.ID, tm.GetTasks()[1].ID); err != nil {
+			t.Fatal(err)
+		}
+		tm.SetSortMode(SortManual)
+		if got := names(tm.GetTasks()); !equalNames(got, []string{"a", "c", "b"}) {
+			t.Errorf("expected alphabetical order with c moved up, got %v", got)
+		}
+		if a.Position != 1 {
+			t.Errorf("expected a to stay first, got position %d", a.Position)
+		}
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		tm := NewTaskManager(nil, nil, 0)
+		task := tm.AddTask("task")
+		if _, err := tm.MoveTask(task.ID, 42); err == nil {
+			t.Error("expected error for an unknown task")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package task
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period counts the tasks created and closed during a day or a week.
+type Period struct {
+	// Start is the first day of the period.
+	Start     time.Time `json:"start"`
+	Created   int       `json:"created"`
+	Completed int       `json:"completed"`
+	Cancelled int       `json:"cancelled"`
+}
+
+// Count is a number of tasks under a name, such as the open tasks of a
+// priority.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Stats summarizes how tasks are created and closed over time.
+type Stats struct {
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
+	Cancelled int `json:"cancelled"`
+	// Days and Weeks count the tasks created and closed per day and per week
+	// starting on Monday, oldest first and ending with the current one.
+	Days  []Period `json:"days"`
+	Weeks []Period `json:"weeks"`
+	// AverageCompletionHours is how long completed tasks took from creation
+	// to completion on average, zero without completed tasks.
+	AverageCompletionHours float64 `json:"average_completion_hours"`
+	// OpenByPriority and OpenByAge count the open tasks, highest priority and
+	// youngest first.
+	OpenByPriority []Count `json:"open_by_priority"`
+	OpenByAge      []Count `json:"open_by_age"`
+	// Streak is the number of days in a row, up to today, on which tasks were
+	// completed. A day without completions yet does not break it until it is
+	// over. BestStreak is the longest such run.
+	Streak     int `json:"streak"`
+	BestStreak int `json:"best_streak"`
+}
+
+// ageBuckets are the ages open tasks are counted by, each up to its limit.
+var ageBuckets = []struct {
+	name  string
+	limit time.Duration
+}{
+	{"under a day", 24 * time.Hour},
+	{"under a week", 7 * 24 * time.Hour},
+	{"under a month", 30 * 24 * time.Hour},
+	{"older", 0},
+}
+
+// ComputeStats summarizes open and closed tasks as of now, counting the last
+// days days and weeks weeks. Cancelled tasks are counted apart from completed
+// ones and do not count towards the time to complete or the streak.
+func ComputeStats(tasks []*Task, now time.Time, days, weeks int) Stats {
+	today := StartOfDay(now)
+	thisWeek := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	stats := Stats{
+		Days:           make([]Period, days),
+		Weeks:          make([]Period, weeks),
+		OpenByPriority: make([]Count, 0, 4),
+		OpenByAge:      make([]Count, len(ageBuckets)),
+	}
+	for i := range stats.Days {
+		stats.Days[i].Start = today.AddDate(0, 0, i-days+1)
+	}
+	for i := range stats.Weeks {
+		stats.Weeks[i].Start = thisWeek.AddDate(0, 0, 7*(i-weeks+1))
+	}
+	for _, p := range []Priority{PriorityHigh, PriorityMedium, PriorityLow, PriorityNone} {
+		stats.OpenByPriority = append(stats.OpenByPriority, Count{Name: p.String()})
+	}
+	for i, bucket := range ageBuckets {
+		stats.OpenByAge[i].Name = bucket.name
+	}
+
+	dayEnd, weekEnd := today.AddDate(0, 0, 1), thisWeek.AddDate(0, 0, 7)
+	tally := func(at time.Time, count func(*Period)) {
+		countPeriod(stats.Days, dayEnd, at, count)
+		countPeriod(stats.Weeks, weekEnd, at, count)
+	}
+
+	var completionTime time.Duration
+	timed := 0
+	completedDays := make(map[time.Time]bool)
+	for _, t := range tasks {
+		tally(t.CreatedAt, func(p *Period) { p.Created++ })
+
+		switch t.CurrentStatus() {
+		case StatusDone:
+			stats.Completed++
+			if closedAt := t.ClosedAt(); closedAt != nil {
+				tally(*closedAt, func(p *Period) { p.Completed++ })
+				completionTime += closedAt.Sub(t.CreatedAt)
+				timed++
+				completedDays[StartOfDay(closedAt.In(now.Location()))] = true
+			}
+		case StatusCancelled:
+			stats.Cancelled++
+			if closedAt := t.ClosedAt(); closedAt != nil {
+				tally(*closedAt, func(p *Period) { p.Cancelled++ })
+			}
+		default:
+			stats.Open++
+			for i := range stats.OpenByPriority {
+				if stats.OpenByPriority[i].Name == t.Priority.String() {
+					stats.OpenByPriority[i].Count++
+				}
+			}
+			age := now.Sub(t.CreatedAt)
+			for i, bucket := range ageBuckets {
+				if bucket.limit == 0 || age < bucket.limit {
+					stats.OpenByAge[i].Count++
+					break
+				}
+			}
+		}
+	}
+
+	if timed > 0 {
+		stats.AverageCompletionHours = completionTime.Hours() / float64(timed)
+	}
+	stats.Streak, stats.BestStreak = streaks(completedDays, today)
+	return stats
+}
+
+// countPeriod counts a time in the period it falls in, if any. Periods are
+// consecutive, each ending where the next one starts and the last one at end.
+func countPeriod(periods []Period, end, at time.Time, count func(*Period)) {
+	if !at.Before(end) {
+		return
+	}
+	for i := len(periods) - 1; i >= 0; i-- {
+		if !at.Before(periods[i].Start) {
+			count(&periods[i])
+			return
+		}
+	}
+}
+
+// streaks returns the current and the longest run of consecutive days with
+// completions, the current one ending today or yesterday.
+func streaks(days map[time.Time]bool, today time.Time) (current, best int) {
+	for day := range days {
+		// Only count each run from its first day
+		if days[day.AddDate(0, 0, -1)] {
+			continue
+		}
+		run := 0
+		for days[day.AddDate(0, 0, run)] {
+			run++
+		}
+		best = max(best, run)
+		if end := day.AddDate(0, 0, run-1); end.Equal(today) || end.Equal(today.AddDate(0, 0, -1)) {
+			current = run
+		}
+	}
+	return current, best
+}
+
+// FormatDuration formats a duration in whole minutes with at most two units,
+// e.g. "45m", "1h 30m" or "2d 9h".
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	days, hours := minutes/(24*60), minutes/60%24
+	minutes %= 60
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
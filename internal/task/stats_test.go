@@ -0,0 +1,83 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeStats(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC) // a Wednesday
+	at := func(days, hour int) time.Time {
+		return StartOfDay(now).AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+	}
+	closed := func(days, hour int) *time.Time {
+		at := at(days, hour)
+		return &at
+	}
+	tasks := []*Task{
+		{ID: 1, Name: "ship", CreatedAt: at(-2, 9), IsDone: true, CompletedAt: closed(0, 9)},
+		{ID: 2, Name: "plan", CreatedAt: at(-1, 9), IsDone: true, CompletedAt: closed(-1, 11)},
+		{ID: 3, Name: "fix", CreatedAt: at(-10, 9), IsDone: true, CompletedAt: closed(-5, 9)},
+		{ID: 4, Name: "drop", CreatedAt: at(-1, 10), IsDone: true, Status: StatusCancelled, StatusAt: map[Status]time.Time{StatusCancelled: at(0, 10)}},
+		{ID: 5, Name: "call", CreatedAt: at(0, 8), Priority: PriorityHigh},
+		{ID: 6, Name: "read", CreatedAt: at(-40, 8)},
+		{ID: 7, Name: "old", CreatedAt: at(-400, 8), IsDone: true},
+	}
+
+	stats := ComputeStats(tasks, now, 7, 2)
+
+	if stats.Open != 2 || stats.Completed != 4 || stats.Cancelled != 1 {
+		t.Errorf("expected 2 open, 4 completed and 1 cancelled, got %d, %d and %d", stats.Open, stats.Completed, stats.Cancelled)
+	}
+	today := stats.Days[len(stats.Days)-1]
+	if !today.Start.Equal(StartOfDay(now)) || today.Created != 1 || today.Completed != 1 || today.Cancelled != 1 {
+		t.Errorf("expected today to count 1 created, completed and cancelled, got %+v", today)
+	}
+	if yesterday := stats.Days[len(stats.Days)-2]; yesterday.Created != 2 || yesterday.Completed != 1 {
+		t.Errorf("expected yesterday to count 2 created and 1 completed, got %+v", yesterday)
+	}
+	thisWeek, lastWeek := stats.Weeks[1], stats.Weeks[0]
+	if thisWeek.Start.Weekday() != time.Monday || thisWeek.Completed != 2 || lastWeek.Completed != 1 {
+		t.Errorf("expected weeks from Monday with 2 and 1 completed, got %+v and %+v", thisWeek, lastWeek)
+	}
+	// 48h, 2h and 120h; the completion time of the old task is unknown
+	if stats.AverageCompletionHours != 170.0/3 {
+		t.Errorf("expected an average of %v hours, got %v", 170.0/3, stats.AverageCompletionHours)
+	}
+	if stats.OpenByPriority[0] != (Count{Name: "high", Count: 1}) || stats.OpenByPriority[3] != (Count{Name: "none", Count: 1}) {
+		t.Errorf("expected one open task of high and no priority, got %v", stats.OpenByPriority)
+	}
+	if stats.OpenByAge[0].Count != 1 || stats.OpenByAge[3].Count != 1 {
+		t.Errorf("expected one open task under a day and one older, got %v", stats.OpenByAge)
+	}
+	if stats.Streak != 2 || stats.BestStreak != 2 {
+		t.Errorf("expected a streak of 2 days, got %d (best %d)", stats.Streak, stats.BestStreak)
+	}
+
+	// A streak lasts until a day without completions is over
+	if stats := ComputeStats(tasks, now.AddDate(0, 0, 1), 7, 2); stats.Streak != 2 {
+		t.Errorf("expected the streak to last through the next day, got %d", stats.Streak)
+	}
+	if stats := ComputeStats(tasks, now.AddDate(0, 0, 2), 7, 2); stats.Streak != 0 || stats.BestStreak != 2 {
+		t.Errorf("expected the streak to be broken, got %d (best %d)", stats.Streak, stats.BestStreak)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "0m"},
+		{45 * time.Minute, "45m"},
+		{90 * time.Minute, "1h 30m"},
+		{2 * time.Hour, "2h"},
+		{57*time.Hour + 20*time.Minute, "2d 9h"},
+		{48 * time.Hour, "2d"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.expected {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Status is the state of a task in the workflow.
+type Status string
+
+const (
+	// StatusTodo is an open task nobody works on yet.
+	StatusTodo Status = "todo"
+	// StatusInProgress is an open task being worked on.
+	StatusInProgress Status = "in_progress"
+	// StatusWaiting is an open task waiting for someone or something else.
+	StatusWaiting Status = "waiting"
+	// StatusBlocked is an open task that cannot go on, for reasons outside of
+	// its blocked-by tasks.
+	StatusBlocked Status = "blocked"
+	// StatusDone is a completed task.
+	StatusDone Status = "done"
+	// StatusCancelled is a task that was dropped. It is kept with the
+	// completed tasks but does not count as done.
+	StatusCancelled Status = "cancelled"
+)
+
+// Statuses lists the statuses in workflow order.
+var Statuses = []Status{StatusTodo, StatusInProgress, StatusWaiting, StatusBlocked, StatusDone, StatusCancelled}
+
+var (
+	// ErrTaskDone is returned when changing the status of a completed task,
+	// which has to be reopened instead.
+	ErrTaskDone = errors.New("task is completed")
+	// ErrTransitionNotAllowed is returned when the configured transitions do
+	// not allow a status change.
+	ErrTransitionNotAllowed = errors.New("status change not allowed")
+)
+
+// ParseStatus parses the name of a status such as "in_progress"; spaces and
+// dashes may be used instead of the underscore.
+func ParseStatus(name string) (Status, error) {
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+	for _, status := range Statuses {
+		if Status(name) == status {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status %q", name)
+}
+
+// Label returns the status for display, e.g. "In progress".
+func (s Status) Label() string {
+	label := strings.ReplaceAll(string(s), "_", " ")
+	if label == "" {
+		return ""
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// orTodo returns the status, todo if it is empty.
+func (s Status) orTodo() Status {
+	if s == "" {
+		return StatusTodo
+	}
+	return s
+}
+
+// CurrentStatus returns the status of the task: done or cancelled once it is
+// closed, otherwise its workflow status, todo unless set.
+func (t *Task) CurrentStatus() Status {
+	if t.IsDone {
+		if t.Status == StatusCancelled {
+			return StatusCancelled
+		}
+		return StatusDone
+	}
+	return t.Status.orTodo()
+}
+
+// IsCancelled reports whether the task was cancelled rather than done.
+func (t *Task) IsCancelled() bool {
+	return t.CurrentStatus() == StatusCancelled
+}
+
+// ReopenedStatus returns the status a closed task has once it is reopened:
+// the status it had before it was completed, or todo if it was cancelled.
+func (t *Task) ReopenedStatus() Status {
+	if t.Status == StatusCancelled {
+		return StatusTodo
+	}
+	return t.Status.orTodo()
+}
+
+// StatusSince returns when the task last entered its current status, or nil
+// if that was not recorded.
+func (t *Task) StatusSince() *time.Time {
+	at, ok := t.StatusAt[t.CurrentStatus()]
+	if !ok {
+		return nil
+	}
+	return &at
+}
+
+// Transitions restricts the statuses a task may move to from each status.
+// Statuses without an entry may move to any status.
+type Transitions map[Status][]Status
+
+// ParseTransitions parses transitions written by status name, e.g.
+// {"todo": ["in_progress", "cancelled"]}.
+func ParseTransitions(names map[string][]string) (Transitions, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	transitions := make(Transitions, len(names))
+	for fromName, toNames := range names {
+		from, err := ParseStatus(fromName)
+		if err != nil {
+			return nil, err
+		}
+		allowed := make([]Status, 0, len(toNames))
+		for _, toName := range toNames {
+			to, err := ParseStatus(toName)
+			if err != nil {
+				return nil, err
+			}
+			allowed = append(allowed, to)
+		}
+		transitions[from] = allowed
+	}
+	return transitions, nil
+}
+
+// Allows reports whether a task may move from one status to another.
+func (tr Transitions) Allows(from, to Status) bool {
+	allowed, ok := tr[from]
+	if !ok || from == to {
+		return true
+	}
+	return slices.Contains(allowed, to)
+}
+
+// SetTransitions restricts the status changes of tasks, nil to allow all.
+func (tm *TaskManager) SetTransitions(transitions Transitions) {
+	tm.transitions = transitions
+}
+
+// CheckTransition returns an error wrapping ErrTransitionNotAllowed if the
+// task may not move to the given status.
+func (tm *TaskManager) CheckTransition(t *Task, to Status) error {
+	from := t.CurrentStatus()
+	if !tm.transitions.Allows(from, to) {
+		return fmt.Errorf("%w: %s → %s", ErrTransitionNotAllowed, strings.ToLower(from.Label()), strings.ToLower(to.Label()))
+	}
+	return nil
+}
+
+// SetTaskStatus changes the status of an open or cancelled task. Cancelling a
+// task moves it to the completed tasks and setting another status on a
+// cancelled task reopens it. Tasks are completed with CompleteTask.
+func (tm *TaskManager) SetTaskStatus(id int, status Status) (*Task, error) {
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+	if t.CurrentStatus() == StatusDone {
+		return nil, ErrTaskDone
+	}
+	if status == StatusDone || !slices.Contains(Statuses, status) {
+		return nil, fmt.Errorf("status %q cannot be set directly", status)
+	}
+	if status == t.CurrentStatus() {
+		return t, nil
+	}
+	if err := tm.CheckTransition(t, status); err != nil {
+		return nil, err
+	}
+
+	t.recordChange(ActionTypeStatus, "status "+strings.ToLower(status.Label()))
+	if err := tm.applyStatus(t, status); err != nil {
+		return nil, err
+	}
+	t.markStatus(status, time.Now())
+	return t, nil
+}
+
+// applyStatus sets the status of a task, moving it between the active and the
+// completed tasks when it is cancelled or reopened.
+func (tm *TaskManager) applyStatus(t *Task, status Status) error {
+	if closed := status == StatusCancelled; t.IsDone != closed {
+		if _, err := tm.moveTask(t.ID, closed); err != nil {
+			return err
+		}
+	}
+	t.Status = status
+	if t.Status == StatusTodo {
+		t.Status = ""
+	}
+	return nil
+}
+
+// markStatus records when the task entered a status.
+func (t *Task) markStatus(status Status, at time.Time) {
+	if t.StatusAt == nil {
+		t.StatusAt = make(map[Status]time.Time)
+	}
+	t.StatusAt[status] = at
+}
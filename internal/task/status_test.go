@@ -0,0 +1,126 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatus(t *testing.T) {
+	t.Run("parse and label", func(t *testing.T) {
+		for _, name := range []string{"in_progress", "In Progress", "in-progress"} {
+			if status, err := ParseStatus(name); err != nil || status != StatusInProgress {
+				t.Errorf("expected %q to parse as in progress, got %q (%v)", name, status, err)
+			}
+		}
+		if _, err := ParseStatus("later"); err == nil {
+			t.Error("expected error for unknown status")
+		}
+		if StatusInProgress.Label() != "In progress" {
+			t.Errorf("expected label 'In progress', got %q", StatusInProgress.Label())
+		}
+	})
+
+	t.Run("current status", func(t *testing.T) {
+		task := &Task{ID: 1, Name: "Test"}
+		if task.CurrentStatus() != StatusTodo {
+			t.Errorf("expected a new task to be todo, got %q", task.CurrentStatus())
+		}
+		task.Status = StatusInProgress
+		task.IsDone = true
+		if task.CurrentStatus() != StatusDone {
+			t.Errorf("expected a completed task to be done, got %q", task.CurrentStatus())
+		}
+	})
+
+	t.Run("set task status", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Test")
+
+		if _, err := tm.SetTaskStatus(task.ID, StatusInProgress); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if task.Status != StatusInProgress || task.History[len(task.History)-1].Type != ActionTypeStatus {
+			t.Errorf("expected the status change to be recorded, got %q", task.Status)
+		}
+		if _, err := tm.SetTaskStatus(task.ID, StatusDone); err == nil {
+			t.Error("expected done to be set by completing the task")
+		}
+
+		// The status is kept while completed, so reopening continues the work
+		tm.CompleteTask(task.ID)
+		if _, err := tm.SetTaskStatus(task.ID, StatusTodo); !errors.Is(err, ErrTaskDone) {
+			t.Errorf("expected ErrTaskDone, got %v", err)
+		}
+		tm.UncompleteTask(task.ID)
+		if task.CurrentStatus() != StatusInProgress {
+			t.Errorf("expected the reopened task to be in progress, got %q", task.CurrentStatus())
+		}
+		if _, err := tm.SetTaskStatus(99, StatusTodo); !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("expected ErrTaskNotFound, got %v", err)
+		}
+	})
+	t.Run("cancel and reopen", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Test")
+		tm.SetTaskStatus(task.ID, StatusWaiting)
+
+		if _, err := tm.SetTaskStatus(task.ID, StatusCancelled); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(tm.GetTasks()) != 0 || len(tm.GetDoneTasks()) != 1 || !task.IsCancelled() {
+			t.Fatal("expected the cancelled task to move to the completed tasks")
+		}
+		if task.StatusSince() == nil || task.StatusAt[StatusWaiting].IsZero() {
+			t.Errorf("expected the status times to be recorded, got %v", task.StatusAt)
+		}
+
+		if tm.UncompleteTask(task.ID) == nil || task.CurrentStatus() != StatusTodo {
+			t.Errorf("expected a reopened cancelled task to be todo, got %q", task.CurrentStatus())
+		}
+	})
+
+	t.Run("transitions", func(t *testing.T) {
+		transitions, err := ParseTransitions(map[string][]string{"todo": {"in progress", "cancelled"}, "waiting": {"in_progress"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseTransitions(map[string][]string{"todo": {"later"}}); err == nil {
+			t.Error("expected error for unknown status")
+		}
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		tm.SetTransitions(transitions)
+		task := tm.AddTask("Test")
+
+		if _, err := tm.SetTaskStatus(task.ID, StatusWaiting); !errors.Is(err, ErrTransitionNotAllowed) {
+			t.Errorf("expected todo → waiting to be refused, got %v", err)
+		}
+		if tm.CompleteTask(task.ID) != nil {
+			t.Error("expected todo → done to be refused")
+		}
+		if _, err := tm.SetTaskStatus(task.ID, StatusInProgress); err != nil {
+			t.Errorf("expected todo → in progress to be allowed, got %v", err)
+		}
+		// Statuses without transitions may move anywhere
+		if tm.CompleteTask(task.ID) == nil {
+			t.Error("expected in progress → done to be allowed")
+		}
+	})
+
+	t.Run("undo status changes", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		task := tm.AddTask("Test")
+		at := task.CreatedAt.Add(time.Hour)
+		command := &StatusCommand{TaskID: task.ID, New: StatusCancelled, At: at}
+
+		if err := command.Apply(tm); err != nil || !task.IsCancelled() || !task.StatusAt[StatusCancelled].Equal(at) {
+			t.Fatalf("expected apply to cancel the task, got %q (%v)", task.CurrentStatus(), err)
+		}
+		if err := command.Revert(tm); err != nil || task.IsDone || task.CurrentStatus() != StatusTodo {
+			t.Fatalf("expected revert to reopen the task, got %q (%v)", task.CurrentStatus(), err)
+		}
+		if _, ok := task.StatusAt[StatusCancelled]; ok || len(tm.GetTasks()) != 1 {
+			t.Errorf("expected revert to forget the cancellation, got %v", task.StatusAt)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxTagLength is the maximum number of characters in a tag.
+const MaxTagLength = 30
+
+// NormalizeTag lowercases a tag and strips a leading '#'.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// ValidateTag checks that a normalized tag is non-empty, short and made of
+// letters, digits and the separators - _ / . only.
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return errors.New("tag cannot be empty")
+	}
+	if len([]rune(tag)) > MaxTagLength {
+		return fmt.Errorf("tag %q is too long (maximum %d characters)", tag, MaxTagLength)
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_/.", r) {
+			return fmt.Errorf("tag %q can only contain letters, digits, '-', '_', '/' and '.'", tag)
+		}
+	}
+	return nil
+}
+
+// NormalizeTags normalizes, deduplicates and sorts tags, dropping empty ones.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// HasTag reports whether the task is tagged with tag.
+func (t *Task) HasTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTaskTags replaces the tags of the task with the given ID.
+func (tm *TaskManager) SetTaskTags(id int, tags []string) (*Task, error) {
+	t := tm.FindTaskByID(id)
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	tags = NormalizeTags(tags)
+	for _, tag := range tags {
+		if err := ValidateTag(tag); err != nil {
+			return nil, err
+		}
+	}
+	if FormatTags(tags) == FormatTags(t.Tags) {
+		return t, nil
+	}
+
+	if len(tags) == 0 {
+		t.recordChange(ActionTypeTags, "tags cleared")
+	} else {
+		t.recordChange(ActionTypeTags, "tagged "+FormatTags(tags))
+	}
+	t.Tags = tags
+	return t, nil
+}
+
+// FormatTags formats tags as a space-separated list like "#ops #backend".
+func FormatTags(tags []string) string {
+	parts := make([]string, len(tags))
+	for i, tag := range tags {
+		parts[i] = "#" + tag
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,78 @@
+package task
+
+import "testing"
+
+func TestNormalizeTags(t *testing.T) {
+	tags := NormalizeTags([]string{"#Ops", "backend", "ops", " ", "API"})
+	expected := []string{"api", "backend", "ops"}
+	if len(tags) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, tags)
+	}
+	for i := range tags {
+		if tags[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, tags)
+		}
+	}
+}
+
+func TestValidateTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tag       string
+		expectErr bool
+	}{
+		{"simple", "ops", false},
+		{"separators", "team/backend-v2.1_x", false},
+		{"unicode", "café", false},
+		{"empty", "", true},
+		{"space", "two words", true},
+		{"comma", "a,b", true},
+		{"too long", "abcdefghijabcdefghijabcdefghijk", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTag(test.tag)
+			if test.expectErr && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !test.expectErr && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetTaskTags(t *testing.T) {
+	tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+	task := tm.AddTask("Deploy")
+
+	if _, err := tm.SetTaskTags(task.ID, []string{"Ops", "#backend"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !task.HasTag("ops") || !task.HasTag("#Backend") {
+		t.Errorf("expected task to be tagged, got %v", task.Tags)
+	}
+	if change := task.LastChange(ActionTypeTags); change == nil || change.Summary != "tagged #backend #ops" {
+		t.Errorf("unexpected history entry %+v", change)
+	}
+
+	entries := len(task.History)
+	if _, err := tm.SetTaskTags(task.ID, []string{"backend", "ops"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(task.History) != entries {
+		t.Error("expected unchanged tags not to be recorded")
+	}
+
+	if _, err := tm.SetTaskTags(task.ID, []string{"bad tag"}); err == nil {
+		t.Error("expected invalid tag to be rejected")
+	}
+	if _, err := tm.SetTaskTags(99, []string{"ops"}); err != ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	if _, err := tm.SetTaskTags(task.ID, nil); err != nil || len(task.Tags) != 0 {
+		t.Errorf("expected tags to be cleared, got %v (%v)", task.Tags, err)
+	}
+}
@@ -2,7 +2,9 @@
 package task
 
 import (
-	"sort"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -34,20 +36,57 @@ func (p Priority) String() string {
 	}
 }
 
+// ParsePriority parses the name of a priority: none, low, medium or high.
+func ParsePriority(name string) (Priority, error) {
+	for _, p := range []Priority{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh} {
+		if strings.EqualFold(name, p.String()) {
+			return p, nil
+		}
+	}
+	return PriorityNone, fmt.Errorf("unknown priority %q", name)
+}
+
 // Task represents a todo task with all its properties.
 type Task struct {
-	CreatedAt time.Time `json:"created_at"`
-	Name      string    `json:"name"`
-	ID        int       `json:"id"`
-	IsDone    bool      `json:"is_done"`
-	Priority  Priority  `json:"priority"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Name       string      `json:"name"`
+	ID         int         `json:"id"`
+	IsDone     bool        `json:"is_done"`
+	Priority   Priority    `json:"priority"`
+	BlockedBy  []int       `json:"blocked_by,omitempty"`
+	DueAt      *time.Time  `json:"due_at,omitempty"`
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	Notes      string      `json:"notes,omitempty"`
+	Tags       []string    `json:"tags,omitempty"`
+	History    []Change    `json:"history,omitempty"`
+	// Project is the project the task belongs to, if any.
+	Project string `json:"project,omitempty"`
+	// Status is the workflow status of the task while it is open, empty for
+	// todo. It is kept when the task is completed, see CurrentStatus.
+	Status Status `json:"status,omitempty"`
+	// StatusAt records when the task last entered each status.
+	StatusAt map[Status]time.Time `json:"status_at,omitempty"`
+	// CompletedAt is when the task was completed, nil while it is open or
+	// cancelled.
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// TimeEntries are the spans of time tracked on the task, oldest first.
+	TimeEntries []TimeEntry `json:"time_entries,omitempty"`
+	// Position is the place of the task in manual order; unmoved tasks have
+	// position zero.
+	Position int `json:"position,omitempty"`
+	// NextOccurrenceID is the ID of the occurrence spawned when this
+	// recurring task was completed.
+	NextOccurrenceID int `json:"next_occurrence_id,omitempty"`
 }
 
 // TaskManager manages a collection of tasks and provides business logic operations.
 type TaskManager struct {
-	tasks     []*Task
-	doneTasks []*Task
-	nextID    int
+	tasks       []*Task
+	doneTasks   []*Task
+	nextID      int
+	sortMode    SortMode
+	sortReverse bool
+	transitions Transitions
 }
 
 // NewTaskManager creates a new task manager with the given tasks.
@@ -81,23 +120,52 @@ func (tm *TaskManager) GetNextID() int {
 	return tm.nextID
 }
 
+// reserveID makes sure IDs handed out later do not collide with id, which
+// belongs to a task that only exists in the undo history.
+func (tm *TaskManager) reserveID(id int) {
+	if id > tm.nextID {
+		tm.nextID = id
+	}
+}
+
 // AddTask adds a new task with the given name.
 func (tm *TaskManager) AddTask(name string) *Task {
+	return tm.AddTaskWith(Task{Name: name})
+}
+
+// AddTaskWith adds a new task with the name, priority, tags, project, due date
+// and recurrence of details. The other fields of details are ignored.
+func (tm *TaskManager) AddTaskWith(details Task) *Task {
 	tm.nextID++
 	task := &Task{
-		ID:        tm.nextID,
-		Name:      name,
-		CreatedAt: time.Now(),
-		IsDone:    false,
-		Priority:  PriorityNone,
+		ID:         tm.nextID,
+		Name:       details.Name,
+		CreatedAt:  time.Now(),
+		IsDone:     false,
+		Priority:   details.Priority,
+		Tags:       NormalizeTags(details.Tags),
+		Project:    details.Project,
+		DueAt:      details.DueAt,
+		Recurrence: details.Recurrence,
 	}
+	task.recordChange(ActionTypeAdd, "created")
 	tm.tasks = append(tm.tasks, task)
 	tm.sortTasks()
 	return task
 }
 
-// DeleteTask removes the task with the given ID.
+// DeleteTask removes the task with the given ID. A running timer of the task
+// is stopped, so the time tracked until then is kept with it.
 func (tm *TaskManager) DeleteTask(id int) *Task {
+	task := tm.deleteTask(id)
+	if task != nil {
+		task.stopRunningEntry(time.Now())
+	}
+	return task
+}
+
+// deleteTask removes the task with the given ID and leaves it as it is.
+func (tm *TaskManager) deleteTask(id int) *Task {
 	for i, task := range tm.tasks {
 		if task.ID == id {
 			deleted := task
@@ -115,11 +183,41 @@ func (tm *TaskManager) DeleteTask(id int) *Task {
 	return nil
 }
 
-// CompleteTask marks the task with the given ID as completed.
+// ClearCompleted removes all completed tasks and returns them.
+func (tm *TaskManager) ClearCompleted() []*Task {
+	cleared := tm.doneTasks
+	tm.doneTasks = []*Task{}
+	return cleared
+}
+
+// CompleteTask marks the task with the given ID as completed. Tasks that are
+// still blocked by unfinished tasks, or may not become done under the
+// configured transitions, are not completed. Completing a recurring task
+// spawns its next occurrence, and a running timer of the task is stopped.
 func (tm *TaskManager) CompleteTask(id int) *Task {
+	completedAt := time.Now()
+	task := tm.completeTask(id, completedAt)
+	if task != nil {
+		task.recordChange(ActionTypeComplete, "completed")
+		tm.spawnNextOccurrence(task, completedAt)
+	}
+	return task
+}
+
+// completeTask completes a task like CompleteTask at the given time, without
+// recording it in the task's history or spawning its next occurrence.
+func (tm *TaskManager) completeTask(id int, completedAt time.Time) *Task {
+	if tm.IsBlocked(id) {
+		return nil
+	}
 	for i, task := range tm.tasks {
 		if task.ID == id {
+			if tm.CheckTransition(task, StatusDone) != nil {
+				return nil
+			}
 			task.IsDone = true
+			task.CompletedAt = &completedAt
+			task.stopRunningEntry(completedAt)
 			tm.doneTasks = append(tm.doneTasks, task)
 			tm.tasks = append(tm.tasks[:i], tm.tasks[i+1:]...)
 			tm.sortTasks()
@@ -129,11 +227,85 @@ func (tm *TaskManager) CompleteTask(id int) *Task {
 	return nil
 }
 
-// UncompleteTask marks the completed task with the given ID as active.
+// spawnNextOccurrence adds the next occurrence of a completed recurring task,
+// with the same name, priority, tags, project and notes. The recurrence rule
+// moves to the new occurrence so the series continues there.
+func (tm *TaskManager) spawnNextOccurrence(completed *Task, completedAt time.Time) {
+	if completed.Recurrence == nil {
+		return
+	}
+
+	next := completed.Recurrence.Next(completed.DueAt, completedAt)
+	tm.nextID++
+	occurrence := &Task{
+		ID:         tm.nextID,
+		Name:       completed.Name,
+		CreatedAt:  completedAt,
+		Priority:   completed.Priority,
+		Tags:       slices.Clone(completed.Tags),
+		Project:    completed.Project,
+		Notes:      completed.Notes,
+		DueAt:      &next,
+		Recurrence: completed.Recurrence.anchored(completed.DueAt),
+	}
+	occurrence.recordChange(ActionTypeAdd, fmt.Sprintf("created as next occurrence of #%d", completed.ID))
+	tm.tasks = append(tm.tasks, occurrence)
+	tm.sortTasks()
+
+	completed.Recurrence = nil
+	completed.NextOccurrenceID = occurrence.ID
+}
+
+// removeSpawnedOccurrence reverts spawnNextOccurrence for a task whose
+// completion is being undone and returns the removed occurrence, nil if there
+// is none.
+func (tm *TaskManager) removeSpawnedOccurrence(completed *Task) *Task {
+	if completed.NextOccurrenceID == 0 {
+		return nil
+	}
+	occurrence := tm.DeleteTask(completed.NextOccurrenceID)
+	if occurrence != nil {
+		completed.Recurrence = occurrence.Recurrence
+	}
+	completed.NextOccurrenceID = 0
+	return occurrence
+}
+
+// restoreOccurrence puts back an occurrence removed by removeSpawnedOccurrence
+// when the completion is redone, so it keeps its ID and any later changes to
+// it can be redone too.
+func (tm *TaskManager) restoreOccurrence(completed, occurrence *Task) error {
+	if err := tm.restoreTask(occurrence); err != nil {
+		return err
+	}
+	completed.Recurrence = nil
+	completed.NextOccurrenceID = occurrence.ID
+	return nil
+}
+
+// UncompleteTask marks the completed task with the given ID as active, with
+// the status it had before it was completed. Cancelled tasks become todo.
 func (tm *TaskManager) UncompleteTask(id int) *Task {
+	task := tm.uncompleteTask(id)
+	if task != nil {
+		task.recordChange(ActionTypeUncomplete, "reopened")
+	}
+	return task
+}
+
+// uncompleteTask reopens a task like UncompleteTask, without recording it in
+// the task's history.
+func (tm *TaskManager) uncompleteTask(id int) *Task {
 	for i, task := range tm.doneTasks {
 		if task.ID == id {
+			if tm.CheckTransition(task, task.ReopenedStatus()) != nil {
+				return nil
+			}
 			task.IsDone = false
+			task.CompletedAt = nil
+			if task.Status == StatusCancelled {
+				task.Status = ""
+			}
 			tm.tasks = append(tm.tasks, task)
 			tm.doneTasks = append(tm.doneTasks[:i], tm.doneTasks[i+1:]...)
 			tm.sortTasks()
@@ -145,25 +317,31 @@ func (tm *TaskManager) UncompleteTask(id int) *Task {
 
 // UpdateTaskName changes the name of the task with the given ID.
 func (tm *TaskManager) UpdateTaskName(id int, newName string) *Task {
-	for _, task := range tm.tasks {
-		if task.ID == id {
-			task.Name = newName
-			return task
-		}
+	task := tm.FindTaskByID(id)
+	if task != nil && task.Name != newName {
+		task.recordChange(ActionTypeEdit, fmt.Sprintf("renamed from %q", task.Name))
+		task.Name = newName
 	}
-	for _, task := range tm.doneTasks {
-		if task.ID == id {
-			task.Name = newName
-			return task
-		}
+	return task
+}
+
+// UpdateTaskNotes changes the notes of the task with the given ID.
+func (tm *TaskManager) UpdateTaskNotes(id int, notes string) *Task {
+	task := tm.FindTaskByID(id)
+	if task != nil && task.Notes != notes {
+		task.recordChange(ActionTypeNotes, "notes updated")
+		task.Notes = notes
 	}
-	return nil
+	return task
 }
 
 // SetTaskPriority sets the priority of the task with the given ID.
 func (tm *TaskManager) SetTaskPriority(id int, priority Priority) *Task {
 	for _, task := range tm.tasks {
 		if task.ID == id {
+			if task.Priority != priority {
+				task.recordChange(ActionTypePriority, fmt.Sprintf("priority %s → %s", task.Priority, priority))
+			}
 			task.Priority = priority
 			tm.sortTasks()
 			return task
@@ -172,6 +350,40 @@ func (tm *TaskManager) SetTaskPriority(id int, priority Priority) *Task {
 	return nil
 }
 
+// SetTaskRecurrence sets the recurrence rule of the task with the given ID.
+// A nil rule stops the task from recurring.
+func (tm *TaskManager) SetTaskRecurrence(id int, recurrence *Recurrence) *Task {
+	for _, task := range tm.tasks {
+		if task.ID == id {
+			if recurrence == nil {
+				task.recordChange(ActionTypeRecurrence, "stopped repeating")
+			} else {
+				task.recordChange(ActionTypeRecurrence, "repeats "+recurrence.String())
+			}
+			task.Recurrence = recurrence
+			return task
+		}
+	}
+	return nil
+}
+
+// SetTaskDue sets the due date of the task with the given ID. A nil date
+// removes it.
+func (tm *TaskManager) SetTaskDue(id int, due *time.Time) *Task {
+	task := tm.FindTaskByID(id)
+	if task == nil {
+		return nil
+	}
+	if due == nil {
+		task.recordChange(ActionTypeDue, "due date cleared")
+	} else {
+		task.recordChange(ActionTypeDue, "due "+due.Format("2006-01-02"))
+	}
+	task.DueAt = due
+	tm.sortTasks()
+	return task
+}
+
 // FindTaskByID finds a task by its ID in both active and completed tasks.
 func (tm *TaskManager) FindTaskByID(id int) *Task {
 	for _, task := range tm.tasks {
@@ -187,25 +399,32 @@ func (tm *TaskManager) FindTaskByID(id int) *Task {
 	return nil
 }
 
-// sortTasks sorts tasks by priority (high to low) and then by creation time (newest first).
+// sortTasks keeps both lists in the sort order of the task manager.
 func (tm *TaskManager) sortTasks() {
-	sortTasks(tm.tasks)
-	sortTasks(tm.doneTasks)
+	sortTasksBy(tm.tasks, tm.SortMode(), tm.sortReverse)
+	sortDoneTasks(tm.doneTasks)
 }
 
-// SortTasksByPriority sorts the given tasks by priority and creation time.
-func SortTasksByPriority(tasks []*Task) {
-	sortTasks(tasks)
+// ClosedAt returns when a completed or cancelled task was closed, nil if it
+// is open or the time is unknown. Tasks completed before completion times
+// were stored fall back to their history.
+func (t *Task) ClosedAt() *time.Time {
+	switch t.CurrentStatus() {
+	case StatusDone:
+		if t.CompletedAt != nil {
+			return t.CompletedAt
+		}
+		if change := t.LastChange(ActionTypeComplete); change != nil {
+			return &change.At
+		}
+	case StatusCancelled:
+		return t.StatusSince()
+	}
+	return nil
 }
 
-// sortTasks is the internal sorting function.
-func sortTasks(tasks []*Task) {
-	sort.Slice(tasks, func(i, j int) bool {
-		// First compare priorities (higher priority first)
-		if tasks[i].Priority != tasks[j].Priority {
-			return tasks[i].Priority > tasks[j].Priority
-		}
-		// If priorities are equal, sort by creation time (newest first)
-		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
-	})
+// SortTasksByPriority sorts the given tasks by priority, manual position and
+// creation time.
+func SortTasksByPriority(tasks []*Task) {
+	sortTasksBy(tasks, SortPriority, false)
 }
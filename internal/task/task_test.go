@@ -1,6 +1,8 @@
 package task
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -58,6 +60,12 @@ func TestTask(t *testing.T) {
 			if test.priority.String() != test.expected {
 				t.Errorf("expected %v.String() to be %s, got %s", test.priority, test.expected, test.priority.String())
 			}
+			if parsed, err := ParsePriority(strings.ToUpper(test.expected)); err != nil || parsed != test.priority {
+				t.Errorf("expected %s to parse as %v, got %v (%v)", test.expected, test.priority, parsed, err)
+			}
+		}
+		if _, err := ParsePriority("urgent"); err == nil {
+			t.Error("expected error for unknown priority")
 		}
 	})
 }
@@ -144,6 +152,26 @@ func TestTaskManager(t *testing.T) {
 		}
 	})
 
+	t.Run("add task with details", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{{ID: 1, Name: "Low", Priority: PriorityLow}}, []*Task{}, 1)
+		due := time.Date(2026, 11, 3, 0, 0, 0, 0, time.Local)
+
+		task := tm.AddTaskWith(Task{ID: 9, Name: "Deploy", Priority: PriorityHigh, Tags: []string{"Ops", "api"}, Project: "web", DueAt: &due, IsDone: true})
+
+		if task.ID != 2 || task.IsDone {
+			t.Errorf("expected a new active task #2, got #%d done=%v", task.ID, task.IsDone)
+		}
+		if task.Priority != PriorityHigh || task.Project != "web" || task.DueAt != &due {
+			t.Errorf("expected the details to be kept, got %+v", task)
+		}
+		if FormatTags(task.Tags) != "#api #ops" {
+			t.Errorf("expected normalized tags, got %v", task.Tags)
+		}
+		if tm.GetTasks()[0] != task {
+			t.Error("expected the high priority task to be sorted first")
+		}
+	})
+
 	t.Run("complete task", func(t *testing.T) {
 		task := &Task{ID: 1, Name: "Test", Priority: PriorityNone, IsDone: false}
 		tm := NewTaskManager([]*Task{task}, []*Task{}, 2)
@@ -159,6 +187,9 @@ func TestTaskManager(t *testing.T) {
 		if len(tm.GetDoneTasks()) != 1 {
 			t.Errorf("expected 1 done task, got %d", len(tm.GetDoneTasks()))
 		}
+		if completedTask.CompletedAt == nil || completedTask.ClosedAt() != completedTask.CompletedAt {
+			t.Error("expected the completion time to be recorded")
+		}
 	})
 
 	t.Run("uncomplete task", func(t *testing.T) {
@@ -176,6 +207,29 @@ func TestTaskManager(t *testing.T) {
 		if len(tm.GetDoneTasks()) != 0 {
 			t.Errorf("expected 0 done tasks, got %d", len(tm.GetDoneTasks()))
 		}
+		if uncompletedTask.CompletedAt != nil || uncompletedTask.ClosedAt() != nil {
+			t.Error("expected the completion time to be cleared")
+		}
+	})
+
+	t.Run("done tasks newest first", func(t *testing.T) {
+		now := time.Now()
+		earlier, later := now.Add(-2*time.Hour), now.Add(-time.Hour)
+		old := &Task{ID: 1, Name: "old", IsDone: true}
+		first := &Task{ID: 2, Name: "first", IsDone: true, CompletedAt: &earlier}
+		dropped := &Task{ID: 3, Name: "dropped", IsDone: true, Status: StatusCancelled, StatusAt: map[Status]time.Time{StatusCancelled: later}}
+		open := &Task{ID: 4, Name: "open"}
+		tm := NewTaskManager([]*Task{open}, []*Task{old, first, dropped}, 4)
+
+		tm.CompleteTask(open.ID)
+
+		var ids []int
+		for _, task := range tm.GetDoneTasks() {
+			ids = append(ids, task.ID)
+		}
+		if !slices.Equal(ids, []int{4, 3, 2, 1}) {
+			t.Errorf("expected the most recently closed tasks first, got %v", ids)
+		}
 	})
 
 	t.Run("delete task", func(t *testing.T) {
@@ -192,6 +246,27 @@ func TestTaskManager(t *testing.T) {
 		}
 	})
 
+	t.Run("clear completed", func(t *testing.T) {
+		active := &Task{ID: 1, Name: "Active", Priority: PriorityNone}
+		done := []*Task{
+			{ID: 2, Name: "Done", IsDone: true},
+			{ID: 3, Name: "Also done", IsDone: true},
+		}
+		tm := NewTaskManager([]*Task{active}, done, 4)
+
+		cleared := tm.ClearCompleted()
+
+		if len(cleared) != 2 || cleared[0].ID != 2 || cleared[1].ID != 3 {
+			t.Errorf("expected the completed tasks to be returned, got %v", cleared)
+		}
+		if len(tm.GetDoneTasks()) != 0 {
+			t.Errorf("expected 0 completed tasks, got %d", len(tm.GetDoneTasks()))
+		}
+		if len(tm.GetTasks()) != 1 {
+			t.Errorf("expected active tasks to be kept, got %d", len(tm.GetTasks()))
+		}
+	})
+
 	t.Run("update task name", func(t *testing.T) {
 		task := &Task{ID: 1, Name: "Old Name", Priority: PriorityNone}
 		tm := NewTaskManager([]*Task{task}, []*Task{}, 2)
@@ -0,0 +1,133 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrTimerRunning is returned when starting a timer that already runs.
+	ErrTimerRunning = errors.New("timer is already running")
+	// ErrTimerStopped is returned when stopping a timer that does not run.
+	ErrTimerStopped = errors.New("timer is not running")
+)
+
+// TimeEntry is a span of time tracked on a task.
+type TimeEntry struct {
+	Start time.Time `json:"start"`
+	// End is when the timer was stopped, nil while it runs.
+	End *time.Time `json:"end,omitempty"`
+}
+
+// Duration returns how long the entry lasted, up to now while it runs.
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	return e.Between(e.Start, now, now)
+}
+
+// Between returns how much of the entry falls between from and to, counting a
+// running entry up to now.
+func (e TimeEntry) Between(from, to, now time.Time) time.Duration {
+	end := now
+	if e.End != nil {
+		end = *e.End
+	}
+	start := e.Start
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// RunningEntry returns the time entry whose timer runs, nil if none does.
+func (t *Task) RunningEntry() *TimeEntry {
+	for i := range t.TimeEntries {
+		if t.TimeEntries[i].End == nil {
+			return &t.TimeEntries[i]
+		}
+	}
+	return nil
+}
+
+// stopRunningEntry ends the running time entry of the task at the given time,
+// if its timer runs.
+func (t *Task) stopRunningEntry(at time.Time) {
+	entry := t.RunningEntry()
+	if entry == nil {
+		return
+	}
+	if at.Before(entry.Start) {
+		at = entry.Start
+	}
+	entry.End = &at
+}
+
+// TrackedTime returns the time tracked on the task, counting a running timer
+// up to now.
+func (t *Task) TrackedTime(now time.Time) time.Duration {
+	var total time.Duration
+	for _, entry := range t.TimeEntries {
+		total += entry.Duration(now)
+	}
+	return total
+}
+
+// TrackedBetween returns the time tracked on the task between from and to,
+// counting a running timer up to now.
+func (t *Task) TrackedBetween(from, to, now time.Time) time.Duration {
+	var total time.Duration
+	for _, entry := range t.TimeEntries {
+		total += entry.Between(from, to, now)
+	}
+	return total
+}
+
+// RunningTimer returns the task whose timer runs, nil if no timer does.
+func (tm *TaskManager) RunningTimer() *Task {
+	for _, list := range [][]*Task{tm.tasks, tm.doneTasks} {
+		for _, t := range list {
+			if t.RunningEntry() != nil {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// StartTimer starts tracking time on an open task at the given time. Only one
+// timer runs at a time, so the timer of another task has to be stopped first.
+func (tm *TaskManager) StartTimer(id int, at time.Time) (*Task, error) {
+	t, err := tm.findTask(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.IsDone {
+		return nil, ErrTaskDone
+	}
+	if running := tm.RunningTimer(); running != nil {
+		return nil, fmt.Errorf("%w on #%d", ErrTimerRunning, running.ID)
+	}
+	t.TimeEntries = append(t.TimeEntries, TimeEntry{Start: at})
+	return t, nil
+}
+
+// StopTimer stops the running timer of a task at the given time and returns
+// the finished entry.
+func (tm *TaskManager) StopTimer(id int, at time.Time) (TimeEntry, error) {
+	t, err := tm.findTask(id)
+	if err != nil {
+		return TimeEntry{}, err
+	}
+	entry := t.RunningEntry()
+	if entry == nil {
+		return TimeEntry{}, ErrTimerStopped
+	}
+	t.stopRunningEntry(at)
+	return *entry, nil
+}
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimer(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	t.Run("start and stop", func(t *testing.T) {
+		tm := NewTaskManager(nil, nil, 0)
+		deploy := tm.AddTask("deploy")
+		docs := tm.AddTask("docs")
+
+		if _, err := tm.StartTimer(deploy.ID, start); err != nil {
+			t.Fatalf("expected the timer to start, got %v", err)
+		}
+		if tm.RunningTimer() != deploy || deploy.TrackedTime(start.Add(10*time.Minute)) != 10*time.Minute {
+			t.Fatal("expected the running timer to count up to now")
+		}
+		if _, err := tm.StartTimer(docs.ID, start); !errors.Is(err, ErrTimerRunning) {
+			t.Errorf("expected only one timer to run, got %v", err)
+		}
+
+		entry, err := tm.StopTimer(deploy.ID, start.Add(90*time.Minute))
+		if err != nil || entry.Duration(start.Add(3*time.Hour)) != 90*time.Minute {
+			t.Fatalf("expected a 90 minute entry, got %v (%v)", entry, err)
+		}
+		if tm.RunningTimer() != nil {
+			t.Error("expected no running timer")
+		}
+		if _, err := tm.StopTimer(deploy.ID, start); !errors.Is(err, ErrTimerStopped) {
+			t.Errorf("expected an error stopping a stopped timer, got %v", err)
+		}
+
+		tm.CompleteTask(docs.ID)
+		if _, err := tm.StartTimer(docs.ID, start); !errors.Is(err, ErrTaskDone) {
+			t.Errorf("expected no timer on a completed task, got %v", err)
+		}
+	})
+
+	t.Run("completing a task stops its timer", func(t *testing.T) {
+		tm := NewTaskManager(nil, nil, 0)
+		deploy := tm.AddTask("deploy")
+		if _, err := tm.StartTimer(deploy.ID, time.Now().Add(-time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+
+		tm.CompleteTask(deploy.ID)
+		if tm.RunningTimer() != nil {
+			t.Fatal("expected the timer to stop with the completion")
+		}
+		if end := deploy.TimeEntries[0].End; end == nil || !end.Equal(*deploy.CompletedAt) {
+			t.Errorf("expected the entry to end when the task was completed, got %v", end)
+		}
+	})
+
+	t.Run("deleting a task stops its timer", func(t *testing.T) {
+		tm := NewTaskManager(nil, nil, 0)
+		um := NewUndoManager(10)
+		deploy := tm.AddTask("deploy")
+		if _, err := tm.StartTimer(deploy.ID, time.Now().Add(-time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+
+		um.PushUndo(&DeleteCommand{Task: tm.DeleteTask(deploy.ID)})
+		if deploy.RunningEntry() != nil {
+			t.Fatal("expected the timer to stop with the deletion")
+		}
+		if um.Undo(tm) != nil || tm.RunningTimer() != nil {
+			t.Fatal("expected the restored task to keep its stopped timer")
+		}
+		if tracked := deploy.TrackedTime(time.Now().Add(time.Hour)); tracked < time.Hour || tracked > 2*time.Hour {
+			t.Errorf("expected the hour before the deletion to be tracked, got %v", tracked)
+		}
+	})
+
+	t.Run("tracked between", func(t *testing.T) {
+		end := start.Add(2 * time.Hour)
+		task := &Task{TimeEntries: []TimeEntry{
+			{Start: start, End: &end},
+			{Start: start.Add(24 * time.Hour)},
+		}}
+		now := start.Add(25 * time.Hour)
+		if got := task.TrackedTime(now); got != 3*time.Hour {
+			t.Errorf("expected 3h in total, got %v", got)
+		}
+		if got := task.TrackedBetween(start.Add(time.Hour), start.Add(24*time.Hour+30*time.Minute), now); got != 90*time.Minute {
+			t.Errorf("expected the overlap of 90 minutes, got %v", got)
+		}
+	})
+
+	t.Run("undo and redo", func(t *testing.T) {
+		tm := NewTaskManager(nil, nil, 0)
+		um := NewUndoManager(10)
+		deploy := tm.AddTask("deploy")
+
+		tm.StartTimer(deploy.ID, start)
+		um.PushUndo(&TimerCommand{TaskID: deploy.ID, Start: start})
+		entry, _ := tm.StopTimer(deploy.ID, start.Add(time.Hour))
+		um.PushUndo(&TimerCommand{TaskID: deploy.ID, Start: entry.Start, End: entry.End})
+
+		if um.Undo(tm) != nil || deploy.RunningEntry() == nil {
+			t.Fatal("expected undoing the stop to run the timer again")
+		}
+		if um.Undo(tm) != nil || len(deploy.TimeEntries) != 0 {
+			t.Fatal("expected undoing the start to remove the entry")
+		}
+		if um.Redo(tm) != nil || um.Redo(tm) != nil || deploy.TrackedTime(start.Add(5*time.Hour)) != time.Hour {
+			t.Errorf("expected redo to restore the hour, got %v", deploy.TimeEntries)
+		}
+	})
+}
@@ -1,22 +1,38 @@
 // Package task provides undo/redo functionality for task operations.
 package task
 
-// ActionType represents the type of action that can be undone/redone.
+import (
+	"errors"
+	"time"
+)
+
+// ActionType identifies a kind of change, both of undoable commands and of
+// the entries in a task's history.
 type ActionType string
 
-// Action represents a reversible action on tasks.
-type Action struct {
-	Type     ActionType
-	Task     *Task
-	OldState interface{}
-	NewState interface{}
+var (
+	// ErrNothingToUndo is returned by Undo when there is no command to undo.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned by Redo when there is no command to redo.
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// UndoEntry is a command on the undo or redo stack.
+type UndoEntry struct {
+	Command Command
+	// At is when the command was performed.
+	At time.Time
 }
 
 // UndoManager manages undo and redo operations.
 type UndoManager struct {
-	undoStack []Action
-	redoStack []Action
+	undoStack []UndoEntry
+	redoStack []UndoEntry
 	maxSize   int
+
+	// Commands pushed while a transaction is open, see Begin.
+	pending []Command
+	depth   int
 }
 
 // NewUndoManager creates a new undo manager with the specified maximum stack size.
@@ -25,15 +41,21 @@ func NewUndoManager(maxSize int) *UndoManager {
 		maxSize = DefaultMaxUndoSize
 	}
 	return &UndoManager{
-		undoStack: make([]Action, 0, maxSize),
-		redoStack: make([]Action, 0, maxSize),
+		undoStack: make([]UndoEntry, 0, maxSize),
+		redoStack: make([]UndoEntry, 0, maxSize),
 		maxSize:   maxSize,
 	}
 }
 
-// PushUndo adds an action to the undo stack and clears the redo stack.
-func (um *UndoManager) PushUndo(action Action) {
-	um.undoStack = append(um.undoStack, action)
+// PushUndo records a command that was just performed and clears the redo
+// stack. Inside a transaction the command is held back until Commit.
+func (um *UndoManager) PushUndo(command Command) {
+	if um.depth > 0 {
+		um.pending = append(um.pending, command)
+		return
+	}
+
+	um.undoStack = append(um.undoStack, UndoEntry{Command: command, At: time.Now()})
 	um.redoStack = nil // Clear redo stack when new action is performed
 
 	// Maintain maximum stack size
@@ -42,142 +64,129 @@ func (um *UndoManager) PushUndo(action Action) {
 	}
 }
 
-// CanUndo returns true if there are actions that can be undone.
+// Begin opens a transaction: commands pushed until the matching Commit are
+// recorded as a single batch that is undone and redone as one step.
+// Transactions may be nested; only the outermost Commit records the batch.
+func (um *UndoManager) Begin() {
+	um.depth++
+}
+
+// Commit closes the transaction opened by Begin. Closing the outermost
+// transaction pushes its commands as one batch, if there are any.
+func (um *UndoManager) Commit() {
+	if um.depth == 0 {
+		return
+	}
+	um.depth--
+	if um.depth > 0 {
+		return
+	}
+
+	pending := um.pending
+	um.pending = nil
+	if len(pending) > 0 {
+		um.PushUndo(NewBatch(pending...))
+	}
+}
+
+// Rollback aborts the open transaction, including any enclosing ones, and
+// reverts the commands pushed since Begin so none of them take effect.
+func (um *UndoManager) Rollback(taskManager *TaskManager) {
+	if um.depth == 0 {
+		return
+	}
+	revertCommands(taskManager, um.pending)
+	um.pending = nil
+	um.depth = 0
+}
+
+// InTransaction reports whether a transaction is open.
+func (um *UndoManager) InTransaction() bool {
+	return um.depth > 0
+}
+
+// CanUndo returns true if there are commands that can be undone.
 func (um *UndoManager) CanUndo() bool {
 	return len(um.undoStack) > 0
 }
 
-// CanRedo returns true if there are actions that can be redone.
+// CanRedo returns true if there are commands that can be redone.
 func (um *UndoManager) CanRedo() bool {
 	return len(um.redoStack) > 0
 }
 
-// Undo performs the last undone action.
-func (um *UndoManager) Undo(taskManager *TaskManager) bool {
+// Undo reverts the last recorded command. A command that cannot be reverted,
+// for example because its task no longer exists, is dropped from the undo
+// stack so that the commands before it can still be undone, and its error is
+// returned.
+func (um *UndoManager) Undo(taskManager *TaskManager) error {
 	if len(um.undoStack) == 0 {
-		return false
+		return ErrNothingToUndo
 	}
 
-	lastAction := um.undoStack[len(um.undoStack)-1]
+	last := um.undoStack[len(um.undoStack)-1]
 	um.undoStack = um.undoStack[:len(um.undoStack)-1]
-
-	switch lastAction.Type {
-	case ActionTypeAdd:
-		// Remove the added task
-		taskManager.DeleteTask(lastAction.Task.ID)
-	case ActionTypeDelete:
-		// Restore the deleted task
-		if lastAction.Task.IsDone {
-			taskManager.doneTasks = append(taskManager.doneTasks, lastAction.Task)
-		} else {
-			taskManager.tasks = append(taskManager.tasks, lastAction.Task)
-			taskManager.sortTasks()
-		}
-	case ActionTypeComplete:
-		// Mark as incomplete and move back to active tasks
-		lastAction.Task.IsDone = false
-		taskManager.tasks = append(taskManager.tasks, lastAction.Task)
-		// Remove from done tasks
-		for i, task := range taskManager.doneTasks {
-			if task.ID == lastAction.Task.ID {
-				taskManager.doneTasks = append(taskManager.doneTasks[:i], taskManager.doneTasks[i+1:]...)
-				break
-			}
-		}
-		taskManager.sortTasks()
-	case ActionTypeUncomplete:
-		// Mark as complete and move to done tasks
-		lastAction.Task.IsDone = true
-		taskManager.doneTasks = append(taskManager.doneTasks, lastAction.Task)
-		// Remove from active tasks
-		for i, task := range taskManager.tasks {
-			if task.ID == lastAction.Task.ID {
-				taskManager.tasks = append(taskManager.tasks[:i], taskManager.tasks[i+1:]...)
-				break
-			}
-		}
-	case ActionTypeEdit:
-		// Restore old name
-		if oldName, ok := lastAction.OldState.(string); ok {
-			lastAction.Task.Name = oldName
-		}
-	case ActionTypePriority:
-		// Restore old priority
-		if oldPriority, ok := lastAction.OldState.(Priority); ok {
-			lastAction.Task.Priority = oldPriority
-			taskManager.sortTasks()
-		}
+	if err := last.Command.Revert(taskManager); err != nil {
+		return err
 	}
-
-	um.redoStack = append(um.redoStack, lastAction)
-	return true
+	um.redoStack = append(um.redoStack, last)
+	return nil
 }
 
-// Redo performs the last undone action.
-func (um *UndoManager) Redo(taskManager *TaskManager) bool {
+// Redo re-applies the last undone command. A command that cannot be applied,
+// for example a completion while the task is blocked, stays on the redo stack
+// so it can be redone once that is resolved.
+func (um *UndoManager) Redo(taskManager *TaskManager) error {
 	if len(um.redoStack) == 0 {
-		return false
+		return ErrNothingToRedo
 	}
 
-	lastAction := um.redoStack[len(um.redoStack)-1]
+	last := um.redoStack[len(um.redoStack)-1]
+	if err := last.Command.Apply(taskManager); err != nil {
+		return err
+	}
 	um.redoStack = um.redoStack[:len(um.redoStack)-1]
+	um.undoStack = append(um.undoStack, last)
+	return nil
+}
 
-	var correspondingUndoAction Action
-
-	switch lastAction.Type {
-	case ActionTypeAdd:
-		// Re-add the task
-		taskManager.tasks = append(taskManager.tasks, lastAction.Task)
-		taskManager.sortTasks()
-		correspondingUndoAction = Action{Type: ActionTypeAdd, Task: lastAction.Task}
-	case ActionTypeDelete:
-		// Re-delete the task
-		taskManager.DeleteTask(lastAction.Task.ID)
-		correspondingUndoAction = Action{Type: ActionTypeDelete, Task: lastAction.Task}
-	case ActionTypeComplete:
-		// Re-complete the task
-		taskManager.CompleteTask(lastAction.Task.ID)
-		correspondingUndoAction = Action{Type: ActionTypeComplete, Task: lastAction.Task}
-	case ActionTypeUncomplete:
-		// Re-uncomplete the task
-		taskManager.UncompleteTask(lastAction.Task.ID)
-		correspondingUndoAction = Action{Type: ActionTypeUncomplete, Task: lastAction.Task}
-	case ActionTypeEdit:
-		// Re-apply the edit
-		if newName, ok := lastAction.NewState.(string); ok {
-			currentName := lastAction.Task.Name
-			lastAction.Task.Name = newName
-			correspondingUndoAction = Action{
-				Type:     ActionTypeEdit,
-				Task:     lastAction.Task,
-				OldState: currentName,
-				NewState: newName,
-			}
-		}
-	case ActionTypePriority:
-		// Re-apply the priority change
-		if newPriority, ok := lastAction.NewState.(Priority); ok {
-			currentPriority := lastAction.Task.Priority
-			lastAction.Task.Priority = newPriority
-			taskManager.sortTasks()
-			correspondingUndoAction = Action{
-				Type:     ActionTypePriority,
-				Task:     lastAction.Task,
-				OldState: currentPriority,
-				NewState: newPriority,
-			}
-		}
+// Entries returns the commands that can be undone, oldest first, and the
+// commands that can be redone, in the order Redo applies them.
+func (um *UndoManager) Entries() (undo, redo []UndoEntry) {
+	undo = append([]UndoEntry(nil), um.undoStack...)
+	for i := len(um.redoStack) - 1; i >= 0; i-- {
+		redo = append(redo, um.redoStack[i])
 	}
+	return undo, redo
+}
 
-	if correspondingUndoAction.Type != "" {
-		um.undoStack = append(um.undoStack, correspondingUndoAction)
-	}
+// Position returns the number of commands that can be undone, which is the
+// position in the combined history of Entries.
+func (um *UndoManager) Position() int {
+	return len(um.undoStack)
+}
 
-	return true
+// JumpTo undoes or redoes commands until position commands of the combined
+// history of Entries are in effect. It stops at the first command that cannot
+// be undone or redone and returns its error.
+func (um *UndoManager) JumpTo(taskManager *TaskManager, position int) error {
+	for um.Position() > position {
+		if err := um.Undo(taskManager); err != nil {
+			return err
+		}
+	}
+	for um.Position() < position {
+		if err := um.Redo(taskManager); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// Clear clears both undo and redo stacks.
+// Clear clears both undo and redo stacks and discards any open transaction.
 func (um *UndoManager) Clear() {
 	um.undoStack = nil
 	um.redoStack = nil
+	um.pending = nil
+	um.depth = 0
 }
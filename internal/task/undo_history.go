@@ -0,0 +1,75 @@
+package task
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// UndoHistory is the serializable form of the undo and redo stacks.
+type UndoHistory struct {
+	Undo []UndoEntry `json:"undo"`
+	Redo []UndoEntry `json:"redo"`
+}
+
+// storedEntry is the JSON form of an UndoEntry.
+type storedEntry struct {
+	At time.Time `json:"at"`
+	storedCommand
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e UndoEntry) MarshalJSON() ([]byte, error) {
+	command, err := encodeCommand(e.Command)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(storedEntry{At: e.At, storedCommand: command})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *UndoEntry) UnmarshalJSON(data []byte) error {
+	var stored storedEntry
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	command, err := decodeCommand(stored.storedCommand)
+	if err != nil {
+		return err
+	}
+	*e = UndoEntry{Command: command, At: stored.At}
+	return nil
+}
+
+// Export returns the undo and redo stacks. The commands are shared with the
+// undo manager, so the history should be encoded right away.
+func (um *UndoManager) Export() UndoHistory {
+	return UndoHistory{
+		Undo: append([]UndoEntry(nil), um.undoStack...),
+		Redo: append([]UndoEntry(nil), um.redoStack...),
+	}
+}
+
+// Import replaces the undo and redo stacks with a stored history that belongs
+// to the tasks of taskManager.
+func (um *UndoManager) Import(history UndoHistory, taskManager *TaskManager) {
+	undo, redo := history.Undo, history.Redo
+	if len(undo) > um.maxSize {
+		undo = undo[len(undo)-um.maxSize:]
+	}
+	if len(redo) > um.maxSize {
+		redo = redo[len(redo)-um.maxSize:]
+	}
+
+	// Tasks that only exist in the history keep their IDs
+	for _, entry := range append(append([]UndoEntry(nil), undo...), redo...) {
+		if holder, ok := entry.Command.(taskHolder); ok {
+			for _, t := range holder.heldTasks() {
+				taskManager.reserveID(t.ID)
+			}
+		}
+	}
+
+	um.Clear()
+	um.undoStack = undo
+	um.redoStack = redo
+}
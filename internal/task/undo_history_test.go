@@ -0,0 +1,208 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// reload simulates a restart: tasks and undo history go through JSON and are
+// loaded into a fresh task manager and undo manager.
+func reload(t *testing.T, tm *TaskManager, um *UndoManager) (*TaskManager, *UndoManager) {
+	t.Helper()
+
+	data, err := json.Marshal(um.Export())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded UndoHistory
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	copyTasks := func(tasks []*Task) []*Task {
+		data, err := json.Marshal(tasks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var copied []*Task
+		if err := json.Unmarshal(data, &copied); err != nil {
+			t.Fatal(err)
+		}
+		return copied
+	}
+	newTM := NewTaskManager(copyTasks(tm.GetTasks()), copyTasks(tm.GetDoneTasks()), tm.GetNextID())
+	newUM := NewUndoManager(10)
+	newUM.Import(loaded, newTM)
+	return newTM, newUM
+}
+
+func TestUndoHistory(t *testing.T) {
+	t.Run("undo after reload", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		task := tm.AddTask("Test Task")
+		um.PushUndo(&AddCommand{Task: task})
+		oldPriority := task.Priority
+		tm.SetTaskPriority(task.ID, PriorityHigh)
+		um.PushUndo(&PriorityCommand{
+			TaskID: task.ID,
+			Old:    oldPriority,
+			New:    PriorityHigh,
+		})
+		tm.UpdateTaskName(task.ID, "Renamed")
+		um.PushUndo(&EditCommand{
+			TaskID: task.ID,
+			Old:    "Test Task",
+			New:    "Renamed",
+		})
+
+		tm, um = reload(t, tm, um)
+
+		if um.Undo(tm) != nil {
+			t.Fatal("expected undo edit to succeed")
+		}
+		restored := tm.FindTaskByID(task.ID)
+		if restored.Name != "Test Task" {
+			t.Errorf("expected name to be restored, got %s", restored.Name)
+		}
+		if um.Undo(tm) != nil {
+			t.Fatal("expected undo priority to succeed")
+		}
+		if restored.Priority != oldPriority {
+			t.Errorf("expected priority to be restored, got %v", restored.Priority)
+		}
+		if um.Undo(tm) != nil {
+			t.Fatal("expected undo add to succeed")
+		}
+		if len(tm.GetTasks()) != 0 {
+			t.Error("expected task to be removed")
+		}
+	})
+
+	t.Run("timestamps survive reload", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+		um.PushUndo(&AddCommand{Task: tm.AddTask("Test Task")})
+		before, _ := um.Entries()
+
+		_, um = reload(t, tm, um)
+
+		after, _ := um.Entries()
+		if !after[0].At.Equal(before[0].At) {
+			t.Errorf("expected timestamp %v, got %v", before[0].At, after[0].At)
+		}
+	})
+
+	t.Run("redo after reload", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		task := tm.AddTask("Test Task")
+		um.PushUndo(&AddCommand{Task: task})
+		um.Undo(tm)
+
+		tm, um = reload(t, tm, um)
+
+		if !um.CanRedo() {
+			t.Fatal("expected redo to survive a reload")
+		}
+		if um.Redo(tm) != nil {
+			t.Fatal("expected redo to succeed")
+		}
+		if tm.FindTaskByID(task.ID) == nil {
+			t.Error("expected task to be added again")
+		}
+		if next := tm.AddTask("Another Task"); next.ID == task.ID {
+			t.Errorf("expected a new task ID, got %d again", next.ID)
+		}
+	})
+
+	t.Run("undo delete after reload", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		task := tm.AddTask("Test Task")
+		tm.UpdateTaskName(task.ID, "Renamed")
+		um.PushUndo(&EditCommand{
+			TaskID: task.ID,
+			Old:    "Test Task",
+			New:    "Renamed",
+		})
+		deleted := tm.DeleteTask(task.ID)
+		um.PushUndo(&DeleteCommand{Task: deleted})
+
+		tm, um = reload(t, tm, um)
+
+		um.Undo(tm)
+		restored := tm.FindTaskByID(task.ID)
+		if restored == nil || restored.Name != "Renamed" {
+			t.Fatalf("expected deleted task to be restored, got %v", restored)
+		}
+
+		// The edit refers to the restored task, not a stale copy
+		um.Undo(tm)
+		if restored.Name != "Test Task" {
+			t.Errorf("expected name to be restored, got %s", restored.Name)
+		}
+	})
+
+	t.Run("batch after reload", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		first := tm.AddTask("First")
+		second := tm.AddTask("Second")
+		um.Begin()
+		for _, task := range []*Task{first, second} {
+			tm.CompleteTask(task.ID)
+			um.PushUndo(&CompleteCommand{TaskID: task.ID})
+		}
+		um.Commit()
+
+		tm, um = reload(t, tm, um)
+
+		um.Undo(tm)
+		if len(tm.GetTasks()) != 2 || len(tm.GetDoneTasks()) != 0 {
+			t.Errorf("expected both tasks to be reopened, got %d active and %d done",
+				len(tm.GetTasks()), len(tm.GetDoneTasks()))
+		}
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+		added := tm.AddTask("Added")
+		um.Import(UndoHistory{Undo: []UndoEntry{
+			{Command: &AddCommand{Task: added}},
+			{Command: &EditCommand{TaskID: 42, Old: "Old", New: "New"}},
+		}}, tm)
+
+		if err := um.Undo(tm); !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("expected undo of a command on an unknown task to fail with ErrTaskNotFound, got %v", err)
+		}
+		if um.CanRedo() {
+			t.Error("expected the failed command not to be redoable")
+		}
+		if err := um.Undo(tm); err != nil || len(tm.GetTasks()) != 0 {
+			t.Errorf("expected the command before it to be undone, got %v", err)
+		}
+	})
+
+	t.Run("unknown command kind", func(t *testing.T) {
+		var history UndoHistory
+		data := []byte(`{"undo": [{"at": "2024-01-01T10:00:00Z", "kind": "bogus", "command": {"task_id": 1}}]}`)
+		if err := json.Unmarshal(data, &history); err == nil {
+			t.Error("expected error for an unknown command kind")
+		}
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		var history UndoHistory
+		data := []byte(`{"undo": [{"at": "2024-01-01T10:00:00Z", "kind": "delete", "command": {}}]}`)
+		if err := json.Unmarshal(data, &history); err == nil {
+			t.Error("expected error for a delete command without a task")
+		}
+	})
+}
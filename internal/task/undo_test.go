@@ -1,6 +1,7 @@
 package task
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -17,17 +18,17 @@ func TestUndoManager(t *testing.T) {
 	})
 
 	t.Run("default max size", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
 		um := NewUndoManager(0)
 
 		// Test that it uses default size
 		for i := 0; i < DefaultMaxUndoSize+10; i++ {
-			um.PushUndo(Action{Type: ActionTypeAdd, Task: &Task{ID: i}})
+			um.PushUndo(&AddCommand{Task: tm.AddTask("Test Task")})
 		}
 
 		// Should be able to undo up to the default max size
 		undoCount := 0
-		for um.CanUndo() {
-			um.Undo(NewTaskManager([]*Task{}, []*Task{}, 1))
+		for um.Undo(tm) == nil {
 			undoCount++
 		}
 
@@ -42,14 +43,14 @@ func TestUndoManager(t *testing.T) {
 
 		// Add a task
 		task := tm.AddTask("Test Task")
-		um.PushUndo(Action{Type: ActionTypeAdd, Task: task})
+		um.PushUndo(&AddCommand{Task: task})
 
 		if !um.CanUndo() {
 			t.Error("expected to be able to undo after push")
 		}
 
 		// Undo the add
-		if !um.Undo(tm) {
+		if um.Undo(tm) != nil {
 			t.Error("expected undo to succeed")
 		}
 
@@ -68,13 +69,13 @@ func TestUndoManager(t *testing.T) {
 
 		// Add a task
 		task := tm.AddTask("Test Task")
-		um.PushUndo(Action{Type: ActionTypeAdd, Task: task})
+		um.PushUndo(&AddCommand{Task: task})
 
 		// Undo
 		um.Undo(tm)
 
 		// Redo
-		if !um.Redo(tm) {
+		if um.Redo(tm) != nil {
 			t.Error("expected redo to succeed")
 		}
 
@@ -90,10 +91,10 @@ func TestUndoManager(t *testing.T) {
 		// Add and then delete a task
 		task := tm.AddTask("Test Task")
 		deletedTask := tm.DeleteTask(task.ID)
-		um.PushUndo(Action{Type: ActionTypeDelete, Task: deletedTask})
+		um.PushUndo(&DeleteCommand{Task: deletedTask})
 
 		// Undo the delete
-		if !um.Undo(tm) {
+		if um.Undo(tm) != nil {
 			t.Error("expected undo delete to succeed")
 		}
 
@@ -109,10 +110,10 @@ func TestUndoManager(t *testing.T) {
 		// Add and complete a task
 		task := tm.AddTask("Test Task")
 		completedTask := tm.CompleteTask(task.ID)
-		um.PushUndo(Action{Type: ActionTypeComplete, Task: completedTask})
+		um.PushUndo(&CompleteCommand{TaskID: completedTask.ID})
 
 		// Undo the complete
-		if !um.Undo(tm) {
+		if um.Undo(tm) != nil {
 			t.Error("expected undo complete to succeed")
 		}
 
@@ -122,6 +123,31 @@ func TestUndoManager(t *testing.T) {
 		if len(tm.GetDoneTasks()) != 0 {
 			t.Error("expected task to be removed from done after undo complete")
 		}
+		if task.CompletedAt != nil {
+			t.Error("expected undo complete to clear the completion time")
+		}
+	})
+
+	t.Run("redo keeps the completion time", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		task := tm.AddTask("Test Task")
+		tm.CompleteTask(task.ID)
+		completedAt := *task.CompletedAt
+		um.PushUndo(&CompleteCommand{TaskID: task.ID, CompletedAt: task.CompletedAt})
+		tm.UncompleteTask(task.ID)
+		um.PushUndo(&UncompleteCommand{TaskID: task.ID, CompletedAt: &completedAt})
+
+		if um.Undo(tm) != nil || task.CompletedAt == nil || !task.CompletedAt.Equal(completedAt) {
+			t.Fatal("expected undo reopen to restore the completion time")
+		}
+		if um.Undo(tm) != nil || um.Redo(tm) != nil {
+			t.Fatal("expected undo and redo complete to succeed")
+		}
+		if task.CompletedAt == nil || !task.CompletedAt.Equal(completedAt) {
+			t.Errorf("expected redo to keep the completion time, got %v", task.CompletedAt)
+		}
 	})
 
 	t.Run("undo edit", func(t *testing.T) {
@@ -132,15 +158,14 @@ func TestUndoManager(t *testing.T) {
 		task := tm.AddTask("Original Name")
 		oldName := task.Name
 		tm.UpdateTaskName(task.ID, "New Name")
-		um.PushUndo(Action{
-			Type:     ActionTypeEdit,
-			Task:     task,
-			OldState: oldName,
-			NewState: "New Name",
+		um.PushUndo(&EditCommand{
+			TaskID: task.ID,
+			Old:    oldName,
+			New:    "New Name",
 		})
 
 		// Undo the edit
-		if !um.Undo(tm) {
+		if um.Undo(tm) != nil {
 			t.Error("expected undo edit to succeed")
 		}
 
@@ -149,6 +174,39 @@ func TestUndoManager(t *testing.T) {
 		}
 	})
 
+	t.Run("undo and redo keep the task history in step", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+		task := tm.AddTask("Draft")
+		summaries := func() string {
+			var parts []string
+			for _, change := range task.History {
+				parts = append(parts, change.Summary)
+			}
+			return strings.Join(parts, "; ")
+		}
+		created := summaries()
+
+		tm.UpdateTaskName(task.ID, "Final")
+		um.PushUndo(&EditCommand{TaskID: task.ID, Old: "Draft", New: "Final"})
+		tm.CompleteTask(task.ID)
+		um.PushUndo(&CompleteCommand{TaskID: task.ID, CompletedAt: task.CompletedAt})
+		edited := summaries()
+
+		if um.Undo(tm) != nil || um.Undo(tm) != nil {
+			t.Fatal("expected undo to succeed")
+		}
+		if got := summaries(); got != created {
+			t.Errorf("expected the undone changes to leave the history, got %q", got)
+		}
+		if um.Redo(tm) != nil || um.Redo(tm) != nil {
+			t.Fatal("expected redo to succeed")
+		}
+		if got := summaries(); got != edited {
+			t.Errorf("expected redo to restore the history %q, got %q", edited, got)
+		}
+	})
+
 	t.Run("undo priority change", func(t *testing.T) {
 		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
 		um := NewUndoManager(10)
@@ -157,15 +215,14 @@ func TestUndoManager(t *testing.T) {
 		task := tm.AddTask("Test Task")
 		oldPriority := task.Priority
 		tm.SetTaskPriority(task.ID, PriorityHigh)
-		um.PushUndo(Action{
-			Type:     ActionTypePriority,
-			Task:     task,
-			OldState: oldPriority,
-			NewState: PriorityHigh,
+		um.PushUndo(&PriorityCommand{
+			TaskID: task.ID,
+			Old:    oldPriority,
+			New:    PriorityHigh,
 		})
 
 		// Undo the priority change
-		if !um.Undo(tm) {
+		if um.Undo(tm) != nil {
 			t.Error("expected undo priority change to succeed")
 		}
 
@@ -174,13 +231,204 @@ func TestUndoManager(t *testing.T) {
 		}
 	})
 
+	t.Run("undo notes change", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		task := tm.AddTask("Test Task")
+		tm.UpdateTaskNotes(task.ID, "- first step\n- second step")
+		um.PushUndo(&NotesCommand{
+			TaskID: task.ID,
+			Old:    "",
+			New:    task.Notes,
+		})
+
+		if um.Undo(tm) != nil {
+			t.Error("expected undo notes change to succeed")
+		}
+		if task.Notes != "" {
+			t.Errorf("expected notes to be cleared, got %q", task.Notes)
+		}
+
+		if um.Redo(tm) != nil {
+			t.Error("expected redo notes change to succeed")
+		}
+		if task.Notes != "- first step\n- second step" {
+			t.Errorf("expected notes to be restored, got %q", task.Notes)
+		}
+	})
+
+	t.Run("undo and redo batch", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		first := tm.AddTask("First")
+		second := tm.AddTask("Second")
+		third := tm.AddTask("Third")
+
+		var commands []Command
+		for _, task := range []*Task{first, second} {
+			tm.CompleteTask(task.ID)
+			commands = append(commands, &CompleteCommand{TaskID: task.ID})
+		}
+		tm.SetTaskPriority(third.ID, PriorityHigh)
+		commands = append(commands, &PriorityCommand{TaskID: third.ID, Old: PriorityNone, New: PriorityHigh})
+		um.PushUndo(NewBatch(commands...))
+
+		if um.Undo(tm) != nil {
+			t.Fatal("expected undo batch to succeed")
+		}
+		if len(tm.GetTasks()) != 3 || len(tm.GetDoneTasks()) != 0 {
+			t.Errorf("expected all tasks to be active again, got %d active and %d done",
+				len(tm.GetTasks()), len(tm.GetDoneTasks()))
+		}
+		if third.Priority != PriorityNone {
+			t.Errorf("expected priority to be restored, got %v", third.Priority)
+		}
+		if um.CanUndo() {
+			t.Error("expected the batch to be undone in a single step")
+		}
+
+		if um.Redo(tm) != nil {
+			t.Fatal("expected redo batch to succeed")
+		}
+		if len(tm.GetTasks()) != 1 || len(tm.GetDoneTasks()) != 2 || third.Priority != PriorityHigh {
+			t.Error("expected the whole batch to be re-applied")
+		}
+
+		if um.Undo(tm) != nil {
+			t.Fatal("expected undo after redo to succeed")
+		}
+		if len(tm.GetDoneTasks()) != 0 {
+			t.Errorf("expected no completed tasks, got %d", len(tm.GetDoneTasks()))
+		}
+	})
+
+	t.Run("batch of one command", func(t *testing.T) {
+		command := &AddCommand{Task: &Task{ID: 1}}
+		if batch := NewBatch(command); batch.Kind() != ActionTypeAdd {
+			t.Errorf("expected a single command to stay unwrapped, got %s", batch.Kind())
+		}
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+
+		um.Begin()
+		for _, name := range []string{"First", "Second"} {
+			um.PushUndo(&AddCommand{Task: tm.AddTask(name)})
+		}
+		// Nested transactions join the outer one
+		um.Begin()
+		um.PushUndo(&AddCommand{Task: tm.AddTask("Third")})
+		um.Commit()
+		if um.CanUndo() {
+			t.Error("expected actions to be held back until the outer commit")
+		}
+		um.Commit()
+
+		if um.Undo(tm) != nil {
+			t.Fatal("expected undo transaction to succeed")
+		}
+		if len(tm.GetTasks()) != 0 || um.CanUndo() {
+			t.Errorf("expected all three adds to be undone in one step, got %d tasks", len(tm.GetTasks()))
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+		task := tm.AddTask("Test Task")
+
+		um.Begin()
+		tm.SetTaskPriority(task.ID, PriorityHigh)
+		um.PushUndo(&PriorityCommand{
+			TaskID: task.ID,
+			Old:    PriorityNone,
+			New:    PriorityHigh,
+		})
+		um.Rollback(tm)
+
+		if task.Priority != PriorityNone {
+			t.Errorf("expected rollback to revert the priority, got %v", task.Priority)
+		}
+		if um.CanUndo() || um.InTransaction() {
+			t.Error("expected rollback to discard the transaction")
+		}
+	})
+
+	t.Run("undo clear completed", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
+		um := NewUndoManager(10)
+		for _, name := range []string{"First", "Second", "Third"} {
+			tm.CompleteTask(tm.AddTask(name).ID)
+		}
+		before := tm.GetDoneTasks()
+
+		um.Begin()
+		for _, cleared := range tm.ClearCompleted() {
+			um.PushUndo(&DeleteCommand{Task: cleared})
+		}
+		um.Commit()
+
+		if um.Undo(tm) != nil {
+			t.Fatal("expected undo clear completed to succeed")
+		}
+		after := tm.GetDoneTasks()
+		if len(after) != len(before) {
+			t.Fatalf("expected %d completed tasks to be restored, got %d", len(before), len(after))
+		}
+		for i := range before {
+			if after[i] != before[i] {
+				t.Errorf("expected restored task %d to be %q, got %q", i, before[i].Name, after[i].Name)
+			}
+		}
+
+		if um.Redo(tm) != nil {
+			t.Fatal("expected redo clear completed to succeed")
+		}
+		if len(tm.GetDoneTasks()) != 0 {
+			t.Errorf("expected completed tasks to be cleared again, got %d", len(tm.GetDoneTasks()))
+		}
+	})
+
+	t.Run("entries and jump", func(t *testing.T) {
+		tm := NewTaskManager([]*Task{}, []*Task{}, 0)
+		um := NewUndoManager(10)
+		for _, name := range []string{"First", "Second", "Third"} {
+			um.PushUndo(&AddCommand{Task: tm.AddTask(name)})
+		}
+
+		if um.JumpTo(tm, 1) != nil {
+			t.Fatal("expected jump back to succeed")
+		}
+		if len(tm.GetTasks()) != 1 {
+			t.Errorf("expected only the first task, got %d tasks", len(tm.GetTasks()))
+		}
+		undo, redo := um.Entries()
+		if len(undo) != 1 || len(redo) != 2 {
+			t.Fatalf("expected 1 undo and 2 redo entries, got %d and %d", len(undo), len(redo))
+		}
+		if redo[0].Command.Describe(tm) != `added #2 "Second"` {
+			t.Errorf("expected the next redo first, got %s", redo[0].Command.Describe(tm))
+		}
+		if undo[0].At.IsZero() {
+			t.Error("expected entries to be timestamped")
+		}
+
+		if um.JumpTo(tm, 3) != nil || len(tm.GetTasks()) != 3 {
+			t.Errorf("expected jump forward to restore all tasks, got %d", len(tm.GetTasks()))
+		}
+	})
+
 	t.Run("clear", func(t *testing.T) {
 		tm := NewTaskManager([]*Task{}, []*Task{}, 1)
 		um := NewUndoManager(10)
 
 		// Add some actions
 		task := tm.AddTask("Test Task")
-		um.PushUndo(Action{Type: ActionTypeAdd, Task: task})
+		um.PushUndo(&AddCommand{Task: task})
 
 		um.Clear()
 
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// agendaChromeLines is the number of lines of the agenda that are not rows:
+// the title and its blank line, and the blank line and hint below the rows.
+const agendaChromeLines = 4
+
+// agendaSection is a section of the agenda, such as the tasks due today.
+type agendaSection struct {
+	title string
+	tasks []*task.Task
+}
+
+// startAgenda opens the agenda with the cursor on its first task. Opened from
+// the calendar, it returns to the list the calendar was opened from.
+func (m *Model) startAgenda() {
+	if m.mode != ModeCalendar {
+		m.promptListMode = m.listMode()
+	}
+	m.clearSelection()
+	m.mode = ModeAgenda
+	m.agendaCursor, m.agendaOffset = 0, 0
+}
+
+// agendaSections returns the open tasks of the active list that are overdue
+// or due within the next AgendaDays days, by when they are due: overdue,
+// today, tomorrow and the days after. Filters and views apply as in the list.
+func (m *Model) agendaSections(now time.Time) []agendaSection {
+	m.updateTaskCache()
+	today := task.StartOfDay(now)
+	sections := []agendaSection{
+		{title: "Overdue"},
+		{title: "Today"},
+		{title: "Tomorrow"},
+		{title: fmt.Sprintf("Next %d days", AgendaDays)},
+	}
+	// Tasks belong to the first section whose end is after their due date
+	ends := []time.Time{today, today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), today.AddDate(0, 0, AgendaDays+1)}
+	for _, t := range m.taskCache {
+		if t.DueAt == nil {
+			continue
+		}
+		for i, end := range ends {
+			if t.DueAt.Before(end) {
+				sections[i].tasks = append(sections[i].tasks, t)
+				break
+			}
+		}
+	}
+	for _, section := range sections {
+		sort.SliceStable(section.tasks, func(i, j int) bool {
+			return section.tasks[i].DueAt.Before(*section.tasks[j].DueAt)
+		})
+	}
+	return sections
+}
+
+// agendaTasks returns the tasks of the agenda in the order they are shown.
+func (m *Model) agendaTasks() []*task.Task {
+	var tasks []*task.Task
+	for _, section := range m.agendaSections(time.Now()) {
+		tasks = append(tasks, section.tasks...)
+	}
+	return tasks
+}
+
+// agendaTask returns the task under the agenda cursor, nil if there is none.
+func (m *Model) agendaTask() *task.Task {
+	tasks := m.agendaTasks()
+	if m.agendaCursor < 0 || m.agendaCursor >= len(tasks) {
+		return nil
+	}
+	return tasks[m.agendaCursor]
+}
+
+// goToTask closes the agenda or calendar and selects a task in the active list.
+func (m *Model) goToTask(t *task.Task) {
+	m.mode = ModeNormal
+	m.invalidateCache()
+	m.followTask(t.ID)
+}
+
+// agendaUpdate handles updates in the agenda.
+func (m *Model) agendaUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		total := len(m.agendaTasks())
+		switch {
+		case key.Matches(msg, m.keys.Escape), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.Agenda):
+			m.mode = m.promptListMode
+		case key.Matches(msg, m.keys.Calendar):
+			m.startCalendar()
+		case key.Matches(msg, m.keys.Enter):
+			if t := m.agendaTask(); t != nil {
+				m.goToTask(t)
+			}
+		case key.Matches(msg, m.keys.Up):
+			m.agendaCursor = max(m.agendaCursor-1, 0)
+		case key.Matches(msg, m.keys.Down):
+			m.agendaCursor = max(min(m.agendaCursor+1, total-1), 0)
+		case key.Matches(msg, m.keys.Home):
+			m.agendaCursor = 0
+		case key.Matches(msg, m.keys.End):
+			m.agendaCursor = max(total-1, 0)
+		}
+	}
+
+	return m, nil
+}
+
+// agendaView renders the agenda, one section after the other.
+func (m *Model) agendaView() string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("%v\n\n", termenv.String("AGENDA").Bold().Underline()))
+
+	var lines []string
+	cursorLine, index := 0, 0
+	for _, section := range m.agendaSections(time.Now()) {
+		header := m.inputStyle.Bold(true)
+		if section.title == "Overdue" && len(section.tasks) > 0 {
+			header = header.Foreground(lipgloss.Color(m.config.Theme.HighPriorityColor))
+		}
+		lines = append(lines, header.Render(fmt.Sprintf("%s (%d)", section.title, len(section.tasks))))
+		if len(section.tasks) == 0 {
+			lines = append(lines, mutedStyle.Render("  Nothing due"))
+		}
+		for _, t := range section.tasks {
+			cursor := termenv.String(" ")
+			if index == m.agendaCursor {
+				cursor = termenv.String(">").Foreground(termenv.ANSIYellow)
+				cursorLine = len(lines)
+			}
+			line := fmt.Sprintf("%v #%d: %s", cursor, t.ID, m.taskView(t, index == m.agendaCursor))
+			if m.width > 0 {
+				line = lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+			}
+			lines = append(lines, line)
+			index++
+		}
+	}
+
+	// Only render the lines that fit on screen, keeping the cursor visible
+	start, end := 0, len(lines)
+	if rows := m.height - agendaChromeLines; m.height > 0 {
+		rows = max(rows, 1)
+		m.agendaOffset = scrollOffset(m.agendaOffset, rows, len(lines), cursorLine)
+		start, end = m.agendaOffset, min(m.agendaOffset+rows, len(lines))
+	}
+	s.WriteString(strings.Join(lines[start:end], "\n") + "\n")
+
+	s.WriteString("\n" + mutedStyle.Render("↑/↓ task · enter go to task · M calendar · esc close") + "\n")
+	return s.String()
+}
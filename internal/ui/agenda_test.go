@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+// newDueModel returns a model with a task due on each of the given days from
+// today.
+func newDueModel(t *testing.T, days ...int) (*Model, []*task.Task) {
+	t.Helper()
+	tm := task.NewTaskManager(nil, nil, 0)
+	tasks := make([]*task.Task, len(days))
+	for i, days := range days {
+		tasks[i] = tm.AddTask(fmt.Sprintf("due in %d days", days))
+		due := task.StartOfDay(time.Now()).AddDate(0, 0, days).Add(9 * time.Hour)
+		tm.SetTaskDue(tasks[i].ID, &due)
+	}
+	m, err := NewTestModel(config.DefaultConfig(), tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, tasks
+}
+
+func TestAgenda(t *testing.T) {
+	m, tasks := newDueModel(t, 3, -2, 0, 1, 7, 8)
+	m.taskManager.AddTask("someday")
+	m.invalidateCache()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if m.mode != ModeAgenda {
+		t.Fatalf("expected the agenda, got mode %d", m.mode)
+	}
+
+	want := [][]*task.Task{{tasks[1]}, {tasks[2]}, {tasks[3]}, {tasks[0], tasks[4]}}
+	for i, section := range m.agendaSections(time.Now()) {
+		if !slices.Equal(section.tasks, want[i]) {
+			t.Errorf("expected %s to hold %d tasks in order of due date, got %d", section.title, len(want[i]), len(section.tasks))
+		}
+	}
+
+	view := m.View()
+	for _, want := range []string{"AGENDA", "Overdue (1)", "Today (1)", "Tomorrow (1)", "Next 7 days (2)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the agenda to show %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "someday") || strings.Contains(view, "due in 8 days") {
+		t.Error("expected tasks without a due date or due later to be left out")
+	}
+
+	// Enter selects the task in the list
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || m.getCurrentTask() != tasks[2] {
+		t.Errorf("expected enter to select the task due today, got %v", m.getCurrentTask())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal {
+		t.Errorf("expected esc to close the agenda, got mode %d", m.mode)
+	}
+}
+
+func TestAgendaScrolls(t *testing.T) {
+	m, _ := newDueModel(t, 0, 0, 0, 0, 0, 0, 0, 0)
+	m.height = 10
+	m.startAgenda()
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+
+	view := m.View()
+	if lines := strings.Count(view, "\n"); lines > m.height {
+		t.Errorf("expected the agenda to fit %d lines, got %d:\n%s", m.height, lines, view)
+	}
+	last := m.agendaTasks()[7]
+	if !strings.Contains(view, fmt.Sprintf("#%d:", last.ID)) {
+		t.Errorf("expected the agenda to scroll to the last task, got:\n%s", view)
+	}
+}
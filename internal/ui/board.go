@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// boardLayout is how the board sorts cards into columns.
+type boardLayout int
+
+const (
+	boardByStatus boardLayout = iota
+	boardByPriority
+)
+
+// boardColumn is a column of the board.
+type boardColumn struct {
+	title    string
+	tasks    []*task.Task
+	status   task.Status   // Status of the cards in a status column
+	priority task.Priority // Priority of the cards in a priority column
+}
+
+// startBoard opens the board with the focus on the task under the cursor.
+func (m *Model) startBoard() {
+	current := m.selectedTask()
+	m.promptListMode = m.listMode()
+	m.clearSelection()
+	m.mode = ModeBoard
+	m.boardColumn, m.boardRow = 0, 0
+	if current != nil {
+		m.focusCard(current.ID)
+	}
+}
+
+// closeBoard returns to the list the board was opened from, with the cursor on
+// the focused card if the list shows it.
+func (m *Model) closeBoard() {
+	current := m.boardCard()
+	m.mode = m.promptListMode
+	m.invalidateCache()
+	if current == nil {
+		m.clampCursor(len(m.listTasks()))
+		return
+	}
+	for i, t := range m.listTasks() {
+		if t.ID == current.ID {
+			m.cursor = i + 1
+			return
+		}
+	}
+	m.clampCursor(len(m.listTasks()))
+}
+
+// boardColumns returns the columns of the board. Open cards are those of the
+// active list, so filters and views apply; completed cards are those of the
+// completed list.
+func (m *Model) boardColumns() []boardColumn {
+	m.updateTaskCache()
+	if m.boardLayout == boardByPriority {
+		columns := []boardColumn{
+			{title: "High", priority: task.PriorityHigh},
+			{title: "Medium", priority: task.PriorityMedium},
+			{title: "Low", priority: task.PriorityLow},
+			{title: "None", priority: task.PriorityNone},
+		}
+		for _, t := range m.taskCache {
+			for i := range columns {
+				if columns[i].priority == t.Priority {
+					columns[i].tasks = append(columns[i].tasks, t)
+				}
+			}
+		}
+		return columns
+	}
+
+	var columns []boardColumn
+	for _, status := range task.Statuses {
+		if status != task.StatusCancelled {
+			columns = append(columns, boardColumn{title: status.Label(), status: status})
+		}
+	}
+	column := func(status task.Status) *boardColumn {
+		return &columns[slices.IndexFunc(columns, func(c boardColumn) bool { return c.status == status })]
+	}
+	for _, t := range m.taskCache {
+		// Tasks blocked by unfinished tasks are blocked whatever their status
+		status := t.CurrentStatus()
+		if m.taskManager.IsBlocked(t.ID) {
+			status = task.StatusBlocked
+		}
+		column(status).tasks = append(column(status).tasks, t)
+	}
+	for _, t := range m.taskManager.GetDoneTasks() {
+		if !t.IsCancelled() {
+			column(task.StatusDone).tasks = append(column(task.StatusDone).tasks, t)
+		}
+	}
+	return columns
+}
+
+// boardCard returns the focused card, nil if its column is empty.
+func (m *Model) boardCard() *task.Task {
+	columns := m.boardColumns()
+	if m.boardColumn >= len(columns) {
+		return nil
+	}
+	tasks := columns[m.boardColumn].tasks
+	if m.boardRow < 0 || m.boardRow >= len(tasks) {
+		return nil
+	}
+	return tasks[m.boardRow]
+}
+
+// focusCard moves the focus to the card of a task, if the board shows it.
+func (m *Model) focusCard(id int) {
+	for c, column := range m.boardColumns() {
+		for r, t := range column.tasks {
+			if t.ID == id {
+				m.boardColumn, m.boardRow = c, r
+				return
+			}
+		}
+	}
+	m.clampBoardFocus()
+}
+
+// clampBoardFocus keeps the focus within the cards of its column.
+func (m *Model) clampBoardFocus() {
+	columns := m.boardColumns()
+	m.boardColumn = max(0, min(m.boardColumn, len(columns)-1))
+	m.boardRow = max(0, min(m.boardRow, len(columns[m.boardColumn].tasks)-1))
+}
+
+// moveCard moves the focused card to the next column in the given direction,
+// as one undoable step.
+func (m *Model) moveCard(delta int) tea.Cmd {
+	t := m.boardCard()
+	if t == nil {
+		return nil
+	}
+	if !t.IsDone && m.taskManager.IsBlocked(t.ID) {
+		return m.showStatus(statusWarning, fmt.Sprintf("#%d is blocked by %s", t.ID, m.blockerIDs(t)))
+	}
+
+	columns := m.boardColumns()
+	target := m.boardColumn + delta
+	if target < 0 || target >= len(columns) {
+		return nil
+	}
+
+	if m.boardLayout == boardByPriority {
+		m.setTasksPriority([]*task.Task{t}, columns[target].priority)
+		m.focusCard(t.ID)
+		return nil
+	}
+	if err := m.setTasksStatus([]*task.Task{t}, columns[target].status); err != nil {
+		return m.showStatus(statusError, fmt.Sprintf("Could not move %v", err))
+	}
+	m.focusCard(t.ID)
+	return nil
+}
+
+// blockerIDs formats the IDs of the unfinished tasks blocking a task.
+func (m *Model) blockerIDs(t *task.Task) string {
+	blockers := m.taskManager.Blockers(t.ID)
+	ids := make([]int, len(blockers))
+	for i, blocker := range blockers {
+		ids[i] = blocker.ID
+	}
+	return task.FormatIDs(ids)
+}
+
+// boardUpdate handles updates on the board.
+func (m *Model) boardUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.Board):
+			m.closeBoard()
+		case key.Matches(msg, m.keys.MoveLeft):
+			return m, m.moveCard(-1)
+		case key.Matches(msg, m.keys.MoveRight):
+			return m, m.moveCard(1)
+		case key.Matches(msg, m.keys.Left):
+			m.boardColumn--
+			m.clampBoardFocus()
+		case key.Matches(msg, m.keys.Right):
+			m.boardColumn++
+			m.clampBoardFocus()
+		case key.Matches(msg, m.keys.Up):
+			m.boardRow--
+			m.clampBoardFocus()
+		case key.Matches(msg, m.keys.Down):
+			m.boardRow++
+			m.clampBoardFocus()
+		case key.Matches(msg, m.keys.BoardLayout):
+			current := m.boardCard()
+			m.boardLayout = (m.boardLayout + 1) % 2
+			m.boardColumn, m.boardRow = 0, 0
+			if current != nil {
+				m.focusCard(current.ID)
+			}
+		case key.Matches(msg, m.keys.Undo):
+			cmd := m.undo()
+			m.clampBoardFocus()
+			return m, cmd
+		case key.Matches(msg, m.keys.Redo):
+			cmd := m.redo()
+			m.clampBoardFocus()
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// boardView renders the columns of the board side by side.
+func (m *Model) boardView() string {
+	var s strings.Builder
+	titleStr := "BOARD (by status)"
+	if m.boardLayout == boardByPriority {
+		titleStr = "BOARD (by priority)"
+	}
+	s.WriteString(fmt.Sprintf("%v\n\n", termenv.String(titleStr).Bold().Underline()))
+
+	columns := m.boardColumns()
+	width := m.width
+	if width <= 0 {
+		width = BoardDefaultWidth
+	}
+	// Each column has a border on both sides
+	columnWidth := max(width/len(columns)-2, BoardMinColumnWidth)
+
+	rendered := make([]string, len(columns))
+	for c, column := range columns {
+		border := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#666666")).
+			Width(columnWidth)
+		if c == m.boardColumn {
+			border = border.BorderForeground(lipgloss.Color(m.config.Theme.PrimaryColor))
+		}
+
+		lines := []string{lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%s (%d)", column.title, len(column.tasks)))}
+		for r, t := range column.tasks {
+			lines = append(lines, m.cardView(t, columnWidth, c == m.boardColumn && r == m.boardRow))
+		}
+		rendered[c] = border.Render(strings.Join(lines, "\n"))
+	}
+	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, rendered...) + "\n")
+
+	hint := fmt.Sprintf("←/→ column · ↑/↓ card · H/L move card · c by %s · esc close", m.otherBoardLayout())
+	s.WriteString("\n" + mutedStyle.Render(hint) + "\n")
+
+	return s.String()
+}
+
+// otherBoardLayout names the layout the layout key switches to.
+func (m *Model) otherBoardLayout() string {
+	if m.boardLayout == boardByPriority {
+		return "status"
+	}
+	return "priority"
+}
+
+// cardView renders a card as one line of at most width cells.
+func (m *Model) cardView(t *task.Task, width int, selected bool) string {
+	style := lipgloss.NewStyle().MaxWidth(width)
+	line := fmt.Sprintf("#%d %s", t.ID, t.Name)
+	switch {
+	case selected:
+		return style.Inherit(m.inputStyle).Bold(true).Render("> " + line)
+	case t.IsDone:
+		return style.Inherit(mutedStyle).Render("  " + line)
+	}
+	return style.Render("  " + line)
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestBoard(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	deploy := tm.AddTask("deploy")
+	docs := tm.AddTask("docs")
+	review := tm.AddTask("review")
+	if err := tm.AddDependency(review.ID, deploy.ID); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewTestModel(config.DefaultConfig(), tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.followTask(deploy.ID)
+	press := func(keys ...string) {
+		for _, k := range keys {
+			if k == "esc" {
+				m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+				continue
+			}
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+	columnOf := func(id int) string {
+		for _, column := range m.boardColumns() {
+			for _, t := range column.tasks {
+				if t.ID == id {
+					return column.title
+				}
+			}
+		}
+		return ""
+	}
+
+	press("B")
+	if m.mode != ModeBoard || m.boardCard() != deploy {
+		t.Fatalf("expected the board focused on the selected task, got mode %d", m.mode)
+	}
+	view := m.View()
+	for _, want := range []string{"Todo (2)", "In progress (0)", "Waiting (0)", "Blocked (1)", "Done (0)", "#3 review"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the board to show %q, got:\n%s", want, view)
+		}
+	}
+
+	press("L")
+	if deploy.Status != task.StatusInProgress || columnOf(deploy.ID) != "In progress" || m.boardCard() != deploy {
+		t.Fatalf("expected the card to move to in progress, got %q", deploy.Status)
+	}
+
+	press("L", "L")
+	if deploy.Status != task.StatusBlocked || columnOf(deploy.ID) != "Blocked" {
+		t.Fatalf("expected the card to move to blocked, got %q", deploy.Status)
+	}
+	press("L")
+	if !deploy.IsDone || columnOf(review.ID) != "Todo" || m.boardCard() != deploy {
+		t.Fatal("expected the card to be completed and its dependent unblocked")
+	}
+
+	press("H")
+	if deploy.IsDone || deploy.CurrentStatus() != task.StatusBlocked {
+		t.Fatal("expected moving back to reopen the task")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if !deploy.IsDone {
+		t.Fatal("expected reopening to be undone in one step")
+	}
+	for range 4 {
+		m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	}
+	if deploy.IsDone || deploy.CurrentStatus() != task.StatusTodo || columnOf(review.ID) != "Blocked" {
+		t.Fatalf("expected undo to restore the todo card, got %q", deploy.CurrentStatus())
+	}
+	if len(deploy.StatusAt) != 0 {
+		t.Errorf("expected undo to forget when the statuses were entered, got %v", deploy.StatusAt)
+	}
+
+	// Blocked cards stay where they are
+	m.focusCard(review.ID)
+	press("L")
+	if review.CurrentStatus() != task.StatusTodo || !strings.Contains(m.status.text, "blocked by #1") {
+		t.Errorf("expected a warning for the blocked card, got %q", m.status.text)
+	}
+
+	// By priority, moves change the priority
+	m.focusCard(docs.ID)
+	press("c")
+	if columnOf(docs.ID) != "None" || m.boardCard() != docs {
+		t.Fatal("expected the priority layout to keep the focus")
+	}
+	press("H", "H")
+	if docs.Priority != task.PriorityMedium || m.boardCard() != docs {
+		t.Errorf("expected the card to move to medium priority, got %s", docs.Priority)
+	}
+
+	press("esc")
+	if m.mode != ModeNormal || m.getCurrentTask() != docs {
+		t.Error("expected closing the board to select the focused task")
+	}
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/query"
+	"github.com/voioo/td/internal/task"
+)
+
+// dayLayout formats days, and is the date format of queries.
+const dayLayout = "2006-01-02"
+
+// startCalendar opens the calendar on the day the selected task is due, or
+// on today. Opened from the agenda, it returns to the list the agenda was
+// opened from.
+func (m *Model) startCalendar() {
+	current := m.selectedTask()
+	if m.mode == ModeAgenda {
+		current = m.agendaTask()
+	} else {
+		m.promptListMode = m.listMode()
+	}
+	day := time.Now()
+	if current != nil && current.DueAt != nil {
+		day = *current.DueAt
+	}
+	m.clearSelection()
+	m.mode = ModeCalendar
+	m.calendarDay = task.StartOfDay(day)
+}
+
+// dueOn returns the open tasks of the active list due on a day. Filters and
+// views apply as in the list.
+func (m *Model) dueOn(day time.Time) []*task.Task {
+	m.updateTaskCache()
+	var tasks []*task.Task
+	for _, t := range m.taskCache {
+		if t.DueAt != nil && task.StartOfDay(*t.DueAt).Equal(day) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// dueDays counts the open tasks of the active list due on each day, by day
+// formatted with dayLayout.
+func (m *Model) dueDays() map[string]int {
+	m.updateTaskCache()
+	days := make(map[string]int)
+	for _, t := range m.taskCache {
+		if t.DueAt != nil {
+			days[t.DueAt.Format(dayLayout)]++
+		}
+	}
+	return days
+}
+
+// addMonths moves a day by months, keeping the day of the month where the
+// target month has it and its last day otherwise.
+func addMonths(day time.Time, months int) time.Time {
+	first := time.Date(day.Year(), day.Month()+time.Month(months), 1, 0, 0, 0, 0, day.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day.Day(), last)-1)
+}
+
+// listDay closes the calendar and filters the active list to the tasks due
+// on a day.
+func (m *Model) listDay(day time.Time) {
+	m.mode = ModeNormal
+	m.setQuery(query.MustParse("due:" + day.Format(dayLayout)))
+	m.cursor = 0
+	if len(m.taskCache) > 0 {
+		m.cursor = 1
+	}
+}
+
+// calendarUpdate handles updates in the calendar.
+func (m *Model) calendarUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.Calendar):
+			m.mode = m.promptListMode
+		case key.Matches(msg, m.keys.Agenda):
+			m.startAgenda()
+		case key.Matches(msg, m.keys.Enter):
+			m.listDay(m.calendarDay)
+		case key.Matches(msg, m.keys.Left):
+			m.calendarDay = m.calendarDay.AddDate(0, 0, -1)
+		case key.Matches(msg, m.keys.Right):
+			m.calendarDay = m.calendarDay.AddDate(0, 0, 1)
+		case key.Matches(msg, m.keys.Up):
+			m.calendarDay = m.calendarDay.AddDate(0, 0, -7)
+		case key.Matches(msg, m.keys.Down):
+			m.calendarDay = m.calendarDay.AddDate(0, 0, 7)
+		case key.Matches(msg, m.keys.PageUp):
+			m.calendarDay = addMonths(m.calendarDay, -1)
+		case key.Matches(msg, m.keys.PageDown):
+			m.calendarDay = addMonths(m.calendarDay, 1)
+		case key.Matches(msg, m.keys.Home):
+			m.calendarDay = task.StartOfDay(time.Now())
+		}
+	}
+
+	return m, nil
+}
+
+// calendarView renders the month of the selected day as a grid, followed by
+// the tasks due on the selected day.
+func (m *Model) calendarView() string {
+	var s strings.Builder
+	title := "CALENDAR " + m.calendarDay.Format("January 2006")
+	s.WriteString(fmt.Sprintf("%v\n\n", termenv.String(title).Bold().Underline()))
+	s.WriteString(m.monthGrid(time.Now()) + "\n\n")
+
+	tasks := m.dueOn(m.calendarDay)
+	s.WriteString(m.inputStyle.Bold(true).Render(fmt.Sprintf("%s (%d)", m.calendarDay.Format("Monday, 2 January 2006"), len(tasks))) + "\n")
+	if len(tasks) == 0 {
+		s.WriteString(mutedStyle.Render("  Nothing due") + "\n")
+	}
+	for _, t := range tasks {
+		line := fmt.Sprintf("  #%d: %s", t.ID, m.taskView(t, false))
+		if m.width > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+		}
+		s.WriteString(line + "\n")
+	}
+
+	hint := "←/→ day · ↑/↓ week · pgup/pgdn month · home today · enter list day · A agenda · esc close"
+	s.WriteString("\n" + mutedStyle.Render(hint) + "\n")
+	return s.String()
+}
+
+// monthGrid renders the month of the selected day with a column per weekday,
+// Monday first. Days with tasks due are marked, in red while overdue.
+func (m *Model) monthGrid(now time.Time) string {
+	today := task.StartOfDay(now)
+	due := m.dueDays()
+	cell := lipgloss.NewStyle().Width(CalendarCellWidth).Align(lipgloss.Right)
+
+	var weekdays []string
+	for _, name := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		weekdays = append(weekdays, cell.Inherit(mutedStyle).Render(name+" "))
+	}
+	rows := []string{strings.Join(weekdays, "")}
+
+	first := time.Date(m.calendarDay.Year(), m.calendarDay.Month(), 1, 0, 0, 0, 0, m.calendarDay.Location())
+	// Weeks start on Monday
+	week := make([]string, (int(first.Weekday())+6)%7)
+	for i := range week {
+		week[i] = cell.Render("")
+	}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		label := fmt.Sprintf("%d ", day.Day())
+		style := cell
+		if due[day.Format(dayLayout)] > 0 {
+			label = fmt.Sprintf("%d•", day.Day())
+			style = style.Inherit(m.inputStyle)
+			if day.Before(today) {
+				style = style.Foreground(lipgloss.Color(m.config.Theme.HighPriorityColor))
+			}
+		}
+		if day.Equal(today) {
+			style = style.Bold(true).Underline(true)
+		}
+		if day.Equal(m.calendarDay) {
+			style = style.Reverse(true)
+		}
+		week = append(week, style.Render(label))
+		if len(week) == 7 {
+			rows = append(rows, strings.Join(week, ""))
+			week = nil
+		}
+	}
+	if len(week) > 0 {
+		rows = append(rows, strings.Join(week, ""))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#666666")).
+		Padding(0, 1).
+		Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/task"
+)
+
+func TestCalendar(t *testing.T) {
+	m, tasks := newDueModel(t, 3, 3, 20)
+	m.followTask(tasks[0].ID)
+	press := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			m.Update(msg)
+		}
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	day := task.StartOfDay(*tasks[0].DueAt)
+	if m.mode != ModeCalendar || !m.calendarDay.Equal(day) {
+		t.Fatalf("expected the calendar on the day the task is due, got mode %d on %v", m.mode, m.calendarDay)
+	}
+	view := m.View()
+	for _, want := range []string{day.Format("January 2006"), "Mo  Tu", day.Format("Monday, 2 January 2006") + " (2)", tasks[1].Name, "•"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the calendar to show %q, got:\n%s", want, view)
+		}
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRight}, tea.KeyMsg{Type: tea.KeyDown})
+	if want := day.AddDate(0, 0, 8); !m.calendarDay.Equal(want) {
+		t.Errorf("expected a day and a week later, got %v", m.calendarDay)
+	}
+	if view := m.View(); !strings.Contains(view, "Nothing due") {
+		t.Errorf("expected an empty day, got:\n%s", view)
+	}
+
+	// Enter lists the tasks due on the selected day
+	press(tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || len(m.listTasks()) != 2 || m.getCurrentTask() == nil {
+		t.Errorf("expected the list to show the 2 tasks due that day, got %d", len(m.listTasks()))
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		day    string
+		months int
+		want   string
+	}{
+		{"2026-01-31", 1, "2026-02-28"},
+		{"2026-03-15", -1, "2026-02-15"},
+		{"2026-12-31", 1, "2027-01-31"},
+		{"2026-01-10", -1, "2025-12-10"},
+	}
+	for _, tt := range tests {
+		day, _ := time.ParseInLocation(dayLayout, tt.day, time.Local)
+		if got := addMonths(day, tt.months).Format(dayLayout); got != tt.want {
+			t.Errorf("addMonths(%s, %d) = %s, want %s", tt.day, tt.months, got, tt.want)
+		}
+	}
+}
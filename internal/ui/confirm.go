@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// Actions that ask for confirmation, named like in the skip_confirm config.
+const (
+	confirmDelete         = "delete"
+	confirmBulk           = "bulk"
+	confirmClearCompleted = "clear_completed"
+)
+
+// confirmChoice is a button of a confirm dialog.
+type confirmChoice int
+
+const (
+	choiceYes confirmChoice = iota
+	choiceNo
+	choiceAlways // Yes, and don't ask again for the action
+
+	confirmChoiceCount
+)
+
+// confirmChoiceLabels are the labels of the buttons of a confirm dialog.
+var confirmChoiceLabels = [confirmChoiceCount]string{"Yes", "No", "Yes, don't ask again"}
+
+// confirmDialog is a modal asking whether to go ahead with an action. It is
+// shown instead of the current mode until one of its buttons is picked.
+type confirmDialog struct {
+	prompt     string         // E.g. `Delete #3 "deploy"?`
+	action     func() tea.Cmd // Performs the action
+	skipKey    string         // Name of the action in the skip_confirm config
+	choice     confirmChoice  // Button the cursor is on
+	returnMode Mode           // Mode shown again once the dialog is closed
+	keys       KeyMap
+	color      string // Color of the border and the chosen button
+}
+
+// newConfirmDialog creates a dialog asking prompt, with the cursor on "No".
+func newConfirmDialog(prompt string, action func() tea.Cmd, skipKey string, keys KeyMap, color string) *confirmDialog {
+	return &confirmDialog{
+		prompt:  prompt,
+		action:  action,
+		skipKey: skipKey,
+		choice:  choiceNo,
+		keys:    keys,
+		color:   color,
+	}
+}
+
+// Update moves the cursor between the buttons and reports the button picked,
+// if any.
+func (d *confirmDialog) Update(msg tea.Msg) (choice confirmChoice, picked bool) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, d.keys.Escape), msg.String() == "n", msg.String() == "q", msg.Type == tea.KeyCtrlC:
+			return choiceNo, true
+		case msg.String() == "y":
+			return choiceYes, true
+		case msg.String() == "a":
+			return choiceAlways, true
+		case key.Matches(msg, d.keys.Left), msg.String() == "shift+tab":
+			d.choice = (d.choice + confirmChoiceCount - 1) % confirmChoiceCount
+		case key.Matches(msg, d.keys.Right), msg.String() == "tab":
+			d.choice = (d.choice + 1) % confirmChoiceCount
+		case key.Matches(msg, d.keys.Enter):
+			return d.choice, true
+		}
+	}
+
+	return d.choice, false
+}
+
+// View renders the dialog as a box.
+func (d *confirmDialog) View() string {
+	buttons := make([]string, confirmChoiceCount)
+	for choice, label := range confirmChoiceLabels {
+		style := lipgloss.NewStyle().Padding(0, 1)
+		if confirmChoice(choice) == d.choice {
+			style = style.Reverse(true).Foreground(lipgloss.Color(d.color))
+		}
+		buttons[choice] = style.Render(label)
+	}
+
+	body := strings.Join([]string{
+		lipgloss.NewStyle().Bold(true).Render(d.prompt),
+		"",
+		strings.Join(buttons, "  "),
+		"",
+		mutedStyle.Render("y yes · n no · a don't ask again · ←/→ enter choose"),
+	}, "\n")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(d.color)).
+		Padding(1, 2).
+		Render(body)
+}
+
+// confirm runs an action after asking for confirmation, unless the config
+// says not to ask for it.
+func (m *Model) confirm(skipKey, prompt string, action func() tea.Cmd) tea.Cmd {
+	if m.config.SkipsConfirm(skipKey) {
+		return action()
+	}
+	m.confirmDialog = newConfirmDialog(prompt, action, skipKey, m.keys, m.config.Theme.PrimaryColor)
+	m.confirmDialog.returnMode = m.mode
+	m.mode = ModeConfirm
+	return nil
+}
+
+// confirmUpdate handles updates while a confirm dialog is open.
+func (m *Model) confirmUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.confirmDialog == nil {
+		m.mode = ModeNormal
+		return m, nil
+	}
+	if choice, picked := m.confirmDialog.Update(msg); picked {
+		return m, m.closeDialog(choice)
+	}
+	return m, nil
+}
+
+// closeDialog closes the confirm dialog with a choice and runs the action
+// unless it was declined.
+func (m *Model) closeDialog(choice confirmChoice) tea.Cmd {
+	dialog := m.confirmDialog
+	m.confirmDialog = nil
+	m.mode = dialog.returnMode
+
+	switch choice {
+	case choiceNo:
+		return nil
+	case choiceAlways:
+		m.config.SkipConfirm = append(m.config.SkipConfirm, dialog.skipKey)
+		m.configChanged = true
+	}
+	return dialog.action()
+}
+
+// confirmView renders the confirm dialog in the middle of the screen.
+func (m *Model) confirmView() string {
+	if m.confirmDialog == nil {
+		return ""
+	}
+	box := m.confirmDialog.View()
+	if m.width <= 0 || m.height <= 0 {
+		return box + "\n"
+	}
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, box)
+}
+
+// confirmDelete deletes the selected tasks, or the task under the cursor,
+// after asking for confirmation.
+func (m *Model) confirmDelete() tea.Cmd {
+	if m.hasSelection() {
+		selected := m.selectedTasks()
+		return m.confirm(confirmBulk, fmt.Sprintf("Delete %s?", describeTasks(selected)), func() tea.Cmd {
+			m.deleteTasks(selected)
+			return nil
+		})
+	}
+	t := m.selectedTask()
+	if t == nil {
+		return nil
+	}
+	targets := []*task.Task{t}
+	return m.confirm(confirmDelete, fmt.Sprintf("Delete %s?", describeTasks(targets)), func() tea.Cmd {
+		m.deleteTasks(targets)
+		return nil
+	})
+}
+
+// confirmPriority sets the priority of the selected tasks after asking for
+// confirmation, or of the task under the cursor right away.
+func (m *Model) confirmPriority(priority task.Priority) tea.Cmd {
+	targets := m.targetTasks()
+	if !m.hasSelection() {
+		m.setTasksPriority(targets, priority)
+		return nil
+	}
+	question := fmt.Sprintf("Set the priority of %s to %s?", describeTasks(targets), priority)
+	return m.confirm(confirmBulk, question, func() tea.Cmd {
+		m.setTasksPriority(targets, priority)
+		return nil
+	})
+}
+
+// describeTasks names the tasks an action applies to in a question, e.g.
+// `#3 "deploy"` or "3 tasks".
+func describeTasks(tasks []*task.Task) string {
+	if len(tasks) == 1 {
+		return fmt.Sprintf("#%d %q", tasks[0].ID, tasks[0].Name)
+	}
+	return fmt.Sprintf("%d tasks", len(tasks))
+}
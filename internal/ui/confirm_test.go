@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestConfirmDialog(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	for _, name := range []string{"deploy", "docs", "review", "release"} {
+		tm.AddTask(name)
+	}
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, tm)
+	m.cursor = 1
+	press := func(keys ...string) {
+		for _, k := range keys {
+			switch k {
+			case "enter":
+				m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			case "esc":
+				m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+			case "right":
+				m.Update(tea.KeyMsg{Type: tea.KeyRight})
+			default:
+				m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+			}
+		}
+	}
+
+	first := m.listTasks()[0]
+	press("d")
+	if m.mode != ModeConfirm || !strings.Contains(m.View(), fmt.Sprintf("Delete #%d %q?", first.ID, first.Name)) {
+		t.Fatalf("expected a confirm dialog, got:\n%s", m.View())
+	}
+	press("enter")
+	if m.mode != ModeNormal || len(tm.GetTasks()) != 4 {
+		t.Fatalf("expected the dialog to default to no, got %d tasks", len(tm.GetTasks()))
+	}
+
+	press("d", "y")
+	if len(tm.GetTasks()) != 3 || !m.undoManager.CanUndo() {
+		t.Fatalf("expected yes to delete the task undoably, got %d tasks", len(tm.GetTasks()))
+	}
+
+	// Bulk operations ask separately from single deletes
+	press(" ", " ", "4")
+	if m.mode != ModeConfirm || !strings.Contains(m.View(), "Set the priority of 2 tasks to high?") {
+		t.Fatalf("expected a confirm dialog for the bulk change, got:\n%s", m.View())
+	}
+	press("esc")
+	if m.mode != ModeNormal || m.selectedTasks()[0].Priority != task.PriorityNone || len(m.selectedTasks()) != 2 {
+		t.Fatal("expected escape to cancel the bulk change and keep the selection")
+	}
+	press("enter", "y")
+	if len(tm.GetDoneTasks()) != 2 {
+		t.Fatalf("expected the selected tasks to be completed, got %d", len(tm.GetDoneTasks()))
+	}
+
+	press("C")
+	if m.mode != ModeConfirm || !strings.Contains(m.View(), "Delete all 2 completed tasks?") {
+		t.Fatalf("expected a confirm dialog for clearing completed tasks, got:\n%s", m.View())
+	}
+	press("n")
+
+	// Don't ask again is remembered in the config
+	press("d", "right", "enter")
+	if len(tm.GetTasks()) != 0 || !slices.Contains(cfg.SkipConfirm, confirmDelete) || !m.configChanged {
+		t.Fatalf("expected the delete to be remembered, got %v", cfg.SkipConfirm)
+	}
+	tm.AddTask("again")
+	m.invalidateCache()
+	m.cursor = 1
+	press("d")
+	if m.mode != ModeNormal || len(tm.GetTasks()) != 0 {
+		t.Error("expected deletes to no longer ask")
+	}
+}
+
+func TestConfirmDialogUpdate(t *testing.T) {
+	d := newConfirmDialog("Delete?", nil, confirmDelete, newKeyMap(config.DefaultConfig()), "#FFFFFF")
+	if choice, picked := d.Update(tea.KeyMsg{Type: tea.KeyEnter}); !picked || choice != choiceNo {
+		t.Errorf("expected enter to pick no by default, got %v %v", choice, picked)
+	}
+	if _, picked := d.Update(tea.KeyMsg{Type: tea.KeyRight}); picked || d.choice != choiceAlways {
+		t.Errorf("expected right to move to the next button, got %v", d.choice)
+	}
+	if _, picked := d.Update(tea.KeyMsg{Type: tea.KeyRight}); picked || d.choice != choiceYes {
+		t.Errorf("expected the buttons to wrap around, got %v", d.choice)
+	}
+	if choice, picked := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}); !picked || choice != choiceAlways {
+		t.Errorf("expected a to pick don't ask again, got %v %v", choice, picked)
+	}
+	if !strings.Contains(d.View(), "Delete?") {
+		t.Errorf("expected the prompt in the view, got:\n%s", d.View())
+	}
+}
@@ -6,6 +6,47 @@ const (
 	DefaultDataFile    = "~/.td.json"
 )
 
+// DefaultPageSize is the number of rows moved by page jumps before the
+// terminal size is known.
+const DefaultPageSize = 10
+
+// Detail pane layout
+const (
+	// DetailPaneMinSplitWidth is the terminal width from which the details
+	// pane is shown to the right of the list instead of below it.
+	DetailPaneMinSplitWidth = 100
+	// DetailPaneMinWidth is the minimum width of the details pane when split.
+	DetailPaneMinWidth = 40
+	// DetailHistoryEntries is the number of recent changes shown in the details pane.
+	DetailHistoryEntries = 8
+)
+
+// Board layout
+const (
+	// BoardDefaultWidth is the width of the board before the terminal size is known.
+	BoardDefaultWidth = 100
+	// BoardMinColumnWidth is the minimum width of a board column.
+	BoardMinColumnWidth = 12
+)
+
+// Agenda and calendar layout
+const (
+	// AgendaDays is the number of days ahead the agenda covers.
+	AgendaDays = 7
+	// CalendarCellWidth is the width of a day in the calendar grid.
+	CalendarCellWidth = 4
+)
+
+// Statistics layout
+const (
+	// StatsDays is the number of days the statistics chart day by day.
+	StatsDays = 14
+	// StatsWeeks is the number of weeks the statistics chart week by week.
+	StatsWeeks = 8
+	// StatsBarWidth is the width of the longest bar of a statistics chart.
+	StatsBarWidth = 20
+)
+
 // UI mode names for display
 const (
 	ModeNameNormal   = "Normal"
@@ -13,6 +54,10 @@ const (
 	ModeNameAdd      = "Add Task"
 	ModeNameEdit     = "Edit Task"
 	ModeNameHelp     = "Help"
+	ModeNameBlocked  = "Blocked By"
+	ModeNameRecur    = "Recurrence"
+	ModeNameSearch   = "Search"
+	ModeNameTags     = "Tags"
 )
 
 // Filter mode names
@@ -22,4 +67,5 @@ const (
 	FilterNameLow    = "Low Priority"
 	FilterNameMedium = "Medium Priority"
 	FilterNameHigh   = "High Priority"
+	FilterNameReady  = "Ready"
 )
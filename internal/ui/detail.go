@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// Detail pane styles
+var (
+	detailLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#90CAF9")).Width(11)
+	detailSideStyle  = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder(), false, false, false, true).
+				BorderForeground(lipgloss.Color("#666666")).
+				PaddingLeft(1)
+	detailBottomStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder(), true, false, false, false).
+				BorderForeground(lipgloss.Color("#666666"))
+)
+
+// withDetailPane lays out the task list together with the details pane of
+// the selected task, side by side on wide terminals and stacked otherwise.
+func (m *Model) withDetailPane(list string) string {
+	t := m.selectedTask()
+
+	if m.width >= DetailPaneMinSplitWidth {
+		paneWidth := m.width / 3
+		if paneWidth < DetailPaneMinWidth {
+			paneWidth = DetailPaneMinWidth
+		}
+		listWidth := m.width - paneWidth
+		// Border and padding take two columns of the pane
+		pane := m.detailView(t, paneWidth-2)
+		if m.height > 0 {
+			helpHeight := lipgloss.Height(m.listHelpView())
+			pane = truncateLines(pane, m.height-helpHeight-2)
+		}
+		left := lipgloss.NewStyle().MaxWidth(listWidth).Render(list)
+		left = lipgloss.PlaceHorizontal(listWidth, lipgloss.Left, left)
+		return lipgloss.JoinHorizontal(lipgloss.Top, left, detailSideStyle.Render(pane)) + "\n"
+	}
+
+	pane := m.detailView(t, m.width)
+	if m.width > 0 {
+		pane = detailBottomStyle.Width(m.width).Render(pane)
+	}
+	if m.height > 0 {
+		pane = truncateLines(pane, m.bottomDetailHeight())
+	}
+	return list + pane + "\n"
+}
+
+// truncateLines keeps at most n lines of s.
+func truncateLines(s string, n int) string {
+	if n < 1 {
+		n = 1
+	}
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "\n")
+}
+
+// detailView renders everything known about a task, wrapped to width when positive.
+func (m *Model) detailView(t *task.Task, width int) string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("%v\n", termenv.String("DETAILS").Bold().Underline()))
+	if t == nil {
+		s.WriteString(mutedStyle.Render("No task selected."))
+		return s.String()
+	}
+
+	s.WriteString(wrapIndented("", fmt.Sprintf("#%d %s", t.ID, m.inputStyle.Render(t.Name)), width) + "\n\n")
+
+	timeLayout := "2006-01-02 15:04"
+	s.WriteString(detailRow("Status", m.taskStatus(t), width))
+	s.WriteString(detailRow("Priority", t.Priority.String(), width))
+	s.WriteString(detailRow("Created", t.CreatedAt.Format(timeLayout), width))
+	if closedAt := t.ClosedAt(); closedAt != nil {
+		label := "Completed"
+		if t.IsCancelled() {
+			label = "Cancelled"
+		}
+		s.WriteString(detailRow(label, closedAt.Format(timeLayout), width))
+	}
+	if t.DueAt != nil {
+		s.WriteString(detailRow("Due", t.DueAt.Format("2006-01-02")+" ("+relativeDays(*t.DueAt, time.Now())+")", width))
+	}
+	if t.Project != "" {
+		s.WriteString(detailRow("Project", task.FormatProject(t.Project), width))
+	}
+	if len(t.Tags) > 0 {
+		s.WriteString(detailRow("Tags", task.FormatTags(t.Tags), width))
+	}
+	if t.Recurrence != nil {
+		s.WriteString(detailRow("Repeats", t.Recurrence.String(), width))
+	}
+	if len(t.BlockedBy) > 0 {
+		s.WriteString(detailRow("Blocked by", task.FormatIDs(t.BlockedBy), width))
+	}
+	if len(t.TimeEntries) > 0 {
+		tracked := task.FormatDuration(t.TrackedTime(time.Now()))
+		if t.RunningEntry() != nil {
+			tracked += " (timer running)"
+		}
+		s.WriteString(detailRow("Tracked", tracked, width))
+	}
+
+	s.WriteString("\n" + termenv.String("Notes").Bold().String() + "\n")
+	if t.Notes == "" {
+		s.WriteString(mutedStyle.Render("No notes. Press E to write some.") + "\n")
+	} else {
+		s.WriteString(renderMarkdown(t.Notes, width, m.inputStyle) + "\n")
+	}
+
+	if len(t.History) > 0 {
+		s.WriteString("\n" + termenv.String("History").Bold().String() + "\n")
+		for i := len(t.History) - 1; i >= 0 && i >= len(t.History)-DetailHistoryEntries; i-- {
+			change := t.History[i]
+			entry := mutedStyle.Render(change.At.Format(timeLayout)) + " " + change.Summary
+			s.WriteString(wrapIndented("", entry, width) + "\n")
+		}
+	}
+
+	return strings.TrimRight(s.String(), "\n")
+}
+
+// taskStatus describes the status of a task, whether it is blocked and since
+// when it has its status.
+func (m *Model) taskStatus(t *task.Task) string {
+	status := strings.ToLower(t.CurrentStatus().Label())
+	switch t.CurrentStatus() {
+	case task.StatusTodo:
+		status = "open"
+	case task.StatusDone:
+		status = "completed"
+	}
+	if !t.IsDone {
+		if blockers := m.taskManager.Blockers(t.ID); len(blockers) > 0 {
+			ids := make([]int, len(blockers))
+			for i, blocker := range blockers {
+				ids[i] = blocker.ID
+			}
+			if status == "open" {
+				status = "blocked by " + task.FormatIDs(ids)
+			} else {
+				status += ", blocked by " + task.FormatIDs(ids)
+			}
+		}
+	}
+	if since := t.StatusSince(); since != nil {
+		status += " since " + since.Format("2006-01-02 15:04")
+	}
+	return status
+}
+
+// detailRow renders a labelled row of the details pane.
+func detailRow(label, value string, width int) string {
+	labelWidth := detailLabelStyle.GetWidth()
+	if width > labelWidth {
+		value = lipgloss.NewStyle().Width(width - labelWidth).Render(value)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, detailLabelStyle.Render(label), value) + "\n"
+}
+
+// relativeDays describes the distance between a date and now in whole days.
+func relativeDays(date, now time.Time) string {
+	days := int(math.Round(task.StartOfDay(date).Sub(task.StartOfDay(now)).Hours() / 24))
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days > 1:
+		return fmt.Sprintf("in %d days", days)
+	default:
+		return fmt.Sprintf("%d days ago", -days)
+	}
+}
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	input "github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// editField is a field of a task that can be changed in edit mode.
+type editField int
+
+const (
+	editName editField = iota
+	editPriority
+	editTags
+	editProject
+	editDue
+
+	// editFieldCount is the number of fields of the edit mode.
+	editFieldCount
+)
+
+// editFieldLabels are the labels of the fields in edit mode.
+var editFieldLabels = [editFieldCount]string{"name", "priority", "tags", "project", "due"}
+
+// editInput returns the input of an edit mode field.
+func (m *Model) editInput(field editField) *input.Model {
+	switch field {
+	case editPriority:
+		return &m.editPriorityInput
+	case editTags:
+		return &m.editTagsInput
+	case editProject:
+		return &m.editProjectInput
+	case editDue:
+		return &m.editDueInput
+	default:
+		return &m.editTaskNameInput
+	}
+}
+
+// startEdit opens edit mode for a task with every field prefilled with its
+// current value and the cursor at the end of the name.
+func (m *Model) startEdit(t *task.Task) tea.Cmd {
+	due := ""
+	if t.DueAt != nil {
+		due = t.DueAt.Format("2006-01-02")
+	}
+	values := [editFieldCount]string{t.Name, t.Priority.String(), task.FormatTags(t.Tags), task.FormatProject(t.Project), due}
+	for field, value := range values {
+		in := m.editInput(editField(field))
+		in.SetValue(value)
+		in.CursorEnd()
+	}
+
+	m.editTaskID = t.ID
+	m.editErr = ""
+	m.mode = ModeEdit
+	return m.focusEditField(editName)
+}
+
+// focusEditField moves the focus to another field of the edit mode.
+func (m *Model) focusEditField(field editField) tea.Cmd {
+	m.editInput(m.editField).Blur()
+	m.editField = field
+	return m.editInput(field).Focus()
+}
+
+// editTaskUpdate handles updates in task editing mode. Every key other than
+// the ones switching fields, saving or cancelling edits the focused field.
+func (m *Model) editTaskUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape), msg.Type == tea.KeyCtrlC:
+			m.closeEdit()
+			return m, nil
+		case key.Matches(msg, m.keys.NextField):
+			return m, m.focusEditField((m.editField + 1) % editFieldCount)
+		case key.Matches(msg, m.keys.PrevField):
+			return m, m.focusEditField((m.editField + editFieldCount - 1) % editFieldCount)
+		case key.Matches(msg, m.keys.Enter):
+			if field, err := m.saveEdit(); err != nil {
+				m.editErr = err.Error()
+				return m, m.focusEditField(field)
+			}
+			m.closeEdit()
+			return m, nil
+		}
+	}
+
+	in := m.editInput(m.editField)
+	*in, cmd = in.Update(msg)
+	m.editErr = ""
+	return m, cmd
+}
+
+// closeEdit leaves edit mode.
+func (m *Model) closeEdit() {
+	m.editInput(m.editField).Blur()
+	m.editField = editName
+	m.editErr = ""
+	m.mode = ModeNormal
+}
+
+// saveEdit applies the changed fields to the edited task as a single undoable
+// step. Nothing changes when any field is invalid; the field is returned with
+// the error.
+func (m *Model) saveEdit() (editField, error) {
+	t := m.taskManager.FindTaskByID(m.editTaskID)
+	if t == nil {
+		return editName, task.ErrTaskNotFound
+	}
+
+	name := SanitizeTaskName(m.editTaskNameInput.Value())
+	if err := ValidateTaskName(name); err != nil {
+		return editName, err
+	}
+	priority := task.PriorityNone
+	if value := strings.TrimSpace(m.editPriorityInput.Value()); value != "" {
+		var err error
+		if priority, err = task.ParsePriority(value); err != nil {
+			return editPriority, err
+		}
+	}
+	tags, err := ParseTagEdit(t.Tags, m.editTagsInput.Value())
+	if err != nil {
+		return editTags, err
+	}
+	project := task.NormalizeProject(m.editProjectInput.Value())
+	if project != "" {
+		if err := task.ValidateTag(project); err != nil {
+			return editProject, err
+		}
+	}
+	var due *time.Time
+	if value := strings.ToLower(strings.TrimSpace(m.editDueInput.Value())); value != "" {
+		date, err := parseDueDate(value, time.Now())
+		if err != nil {
+			return editDue, err
+		}
+		due = &date
+	}
+
+	m.undoManager.Begin()
+	if name != t.Name {
+		old := t.Name
+		m.taskManager.UpdateTaskName(t.ID, name)
+		m.undoManager.PushUndo(&task.EditCommand{TaskID: t.ID, Old: old, New: name})
+	}
+	if priority != t.Priority {
+		old := t.Priority
+		m.taskManager.SetTaskPriority(t.ID, priority)
+		m.undoManager.PushUndo(&task.PriorityCommand{TaskID: t.ID, Old: old, New: priority})
+	}
+	if task.FormatTags(tags) != task.FormatTags(t.Tags) {
+		old := t.Tags
+		m.taskManager.SetTaskTags(t.ID, tags)
+		m.undoManager.PushUndo(&task.TagsCommand{TaskID: t.ID, Old: old, New: t.Tags})
+	}
+	if project != t.Project {
+		old := t.Project
+		m.taskManager.SetTaskProject(t.ID, project)
+		m.undoManager.PushUndo(&task.ProjectCommand{TaskID: t.ID, Old: old, New: project})
+	}
+	if !sameDay(due, t.DueAt) {
+		old := t.DueAt
+		m.taskManager.SetTaskDue(t.ID, due)
+		m.undoManager.PushUndo(&task.DueCommand{TaskID: t.ID, Old: old, New: due})
+	}
+	m.undoManager.Commit()
+
+	m.invalidateCache()
+	m.followTask(t.ID)
+	return editName, nil
+}
+
+// sameDay reports whether two optional dates fall on the same day, so saving
+// an unchanged due date keeps its time of day.
+func sameDay(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return task.StartOfDay(*a).Equal(task.StartOfDay(*b))
+}
+
+// editTaskView renders the fields of the task being edited.
+func (m *Model) editTaskView() string {
+	var s strings.Builder
+	title := termenv.String("Edit Mode").Bold().Underline()
+	s.WriteString(fmt.Sprintf("%v\n\nEdit #%d\n\n", title, m.editTaskID))
+
+	for field := editName; field < editFieldCount; field++ {
+		label := fmt.Sprintf("  %-9s", editFieldLabels[field])
+		if field == m.editField {
+			label = m.inputStyle.Render(fmt.Sprintf("> %-9s", editFieldLabels[field]))
+		}
+		s.WriteString(label + m.editInput(field).View() + "\n")
+	}
+
+	if m.editErr != "" {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.HighPriorityColor))
+		s.WriteString("\n" + errStyle.Render(m.editErr) + "\n")
+	}
+	hint := "tab/↑↓ switch field · enter save · esc cancel\n" +
+		"priority: none, low, medium or high · due: today, tomorrow, fri, 2006-01-02, 3d or 2w; empty fields are cleared"
+	s.WriteString("\n" + mutedStyle.Render(hint) + "\n")
+	return s.String()
+}
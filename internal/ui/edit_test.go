@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestEditMode(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	deploy := tm.AddTask("deploy the webiste")
+	tm.SetTaskTags(deploy.ID, []string{"ops"})
+	m := NewModel(config.DefaultConfig(), tm)
+	m.cursor = 1
+
+	typeText := func(text string) {
+		for _, r := range text {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	setField := func(text string) {
+		m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+		typeText(text)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if m.mode != ModeEdit || m.editTaskNameInput.Value() != "deploy the webiste" {
+		t.Fatalf("expected the name to be prefilled, got %q", m.editTaskNameInput.Value())
+	}
+
+	// Fix the typo in the last word and change the other fields
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	typeText("website quickly")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	setField("high")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	setField("+web")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	setField("someday")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeEdit || m.editField != editDue || !strings.Contains(m.View(), "invalid due date") {
+		t.Fatalf("expected an invalid due date to keep the edit open, got:\n%s", m.View())
+	}
+	if deploy.Name != "deploy the webiste" {
+		t.Fatalf("expected nothing to change before saving, got %q", deploy.Name)
+	}
+
+	setField("2026-11-03")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal {
+		t.Fatalf("expected the edit to be saved, got:\n%s", m.View())
+	}
+	if deploy.Name != "deploy the website quickly" || deploy.Priority != task.PriorityHigh ||
+		deploy.Project != "web" || task.FormatTags(deploy.Tags) != "#ops" || deploy.DueAt == nil {
+		t.Errorf("expected every field to be saved, got %+v", deploy)
+	}
+
+	if m.undoManager.Undo(tm) != nil || m.undoManager.CanUndo() {
+		t.Fatal("expected the edit to be a single undo step")
+	}
+	if deploy.Name != "deploy the webiste" || deploy.Priority != task.PriorityNone || deploy.Project != "" || deploy.DueAt != nil {
+		t.Errorf("expected undo to revert every field, got %+v", deploy)
+	}
+
+	// Escape leaves the task alone
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	typeText(" later")
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal || deploy.Name != "deploy the webiste" {
+		t.Errorf("expected escape to cancel the edit, got %q", deploy.Name)
+	}
+}
+
+func TestRecurrencePrompt(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	review := tm.AddTask("review")
+	rule, _ := task.ParseRecurrence("2w!")
+	tm.SetTaskRecurrence(review.ID, rule)
+	m := NewModel(config.DefaultConfig(), tm)
+	m.cursor = 1
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if m.mode != ModeRecurrence || m.recurrenceInput.Value() != "2w!" {
+		t.Fatalf("expected the rule to be prefilled, got %q", m.recurrenceInput.Value())
+	}
+
+	// Saving the prefilled rule unchanged keeps the recurrence
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || review.Recurrence == nil || review.Recurrence.String() != "every 2 weeks after completion" {
+		t.Errorf("expected the recurrence to be kept, got %v", review.Recurrence)
+	}
+}
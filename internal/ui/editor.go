@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/logger"
+	"github.com/voioo/td/internal/task"
+)
+
+// notesEditedMsg is sent when the external editor opened for a task's notes exits.
+type notesEditedMsg struct {
+	taskID int
+	path   string
+	err    error
+}
+
+// editorCommand returns the command that opens path in the user's editor.
+// It honors $VISUAL and $EDITOR, which may include arguments such as "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if strings.TrimSpace(editor) == "" {
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		} else {
+			editor = "vi"
+		}
+	}
+
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// editNotesCmd writes the notes of the task to a temporary file and opens it
+// in the user's editor, suspending the UI until the editor exits.
+func (m *Model) editNotesCmd(t *task.Task) tea.Cmd {
+	file, err := os.CreateTemp("", fmt.Sprintf("td-%d-*.md", t.ID))
+	if err != nil {
+		return func() tea.Msg {
+			return notesEditedMsg{taskID: t.ID, err: fmt.Errorf("failed to create notes file: %w", err)}
+		}
+	}
+	path := file.Name()
+
+	_, err = file.WriteString(t.Notes)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg {
+			return notesEditedMsg{taskID: t.ID, err: fmt.Errorf("failed to write notes file: %w", err)}
+		}
+	}
+
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return notesEditedMsg{taskID: t.ID, path: path, err: err}
+	})
+}
+
+// handleNotesEdited saves the notes written by the external editor back to the task.
+func (m *Model) handleNotesEdited(msg notesEditedMsg) (tea.Model, tea.Cmd) {
+	if msg.path != "" {
+		defer os.Remove(msg.path)
+	}
+	if msg.err != nil {
+		logger.Error("Failed to edit task notes", logger.F("task_id", msg.taskID), logger.F("error", msg.err))
+		return m, m.showStatus(statusError, fmt.Sprintf("Could not edit the notes: %v", msg.err))
+	}
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		logger.Error("Failed to read task notes", logger.F("task_id", msg.taskID), logger.F("error", err))
+		return m, m.showStatus(statusError, fmt.Sprintf("Could not read the notes: %v", err))
+	}
+
+	notes := SanitizeTaskNotes(string(data))
+	if err := ValidateTaskNotes(notes); err != nil {
+		logger.Warn("Rejected task notes", logger.F("task_id", msg.taskID), logger.F("error", err))
+		return m, m.showStatus(statusError, "Notes not saved: "+err.Error())
+	}
+
+	t := m.taskManager.FindTaskByID(msg.taskID)
+	if t == nil || t.Notes == notes {
+		return m, nil
+	}
+
+	oldNotes := t.Notes
+	if updatedTask := m.taskManager.UpdateTaskNotes(t.ID, notes); updatedTask != nil {
+		m.undoManager.PushUndo(&task.NotesCommand{
+			TaskID: updatedTask.ID,
+			Old:    oldNotes,
+			New:    notes,
+		})
+	}
+	return m, nil
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/query"
+)
+
+// setFilter switches to a filter mode. Custom queries are set with
+// setQuery instead.
+func (m *Model) setFilter(filter FilterMode) {
+	m.filter = filter
+	m.query = filterQueries[filter]
+	m.invalidateCache()
+	m.clampCursor(len(m.listTasks()))
+}
+
+// cycleFilter switches to the next filter mode; a custom query is followed by
+// all tasks.
+func (m *Model) cycleFilter() {
+	if m.filter == FilterQuery {
+		m.setFilter(FilterAll)
+		return
+	}
+	m.setFilter((m.filter + 1) % filterModeCount)
+}
+
+// setQuery filters the list with a query, or shows all tasks for an empty one.
+func (m *Model) setQuery(q *query.Query) {
+	if q.String() == "" {
+		m.setFilter(FilterAll)
+		return
+	}
+	m.filter = FilterQuery
+	m.query = q
+	m.invalidateCache()
+	m.clampCursor(len(m.listTasks()))
+}
+
+// startFilter opens the filter prompt prefilled with the current query.
+func (m *Model) startFilter() tea.Cmd {
+	m.filterInput.SetValue("")
+	if m.query != nil {
+		m.filterInput.SetValue(m.query.String())
+		m.filterInput.CursorEnd()
+	}
+	m.filterErr = ""
+	m.mode = ModeFilter
+	return m.filterInput.Focus()
+}
+
+// filterUpdate handles updates at the filter prompt.
+func (m *Model) filterUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape):
+			m.filterInput.Reset()
+			m.mode = ModeNormal
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			q, err := query.Parse(m.filterInput.Value())
+			if err != nil {
+				m.filterErr = err.Error()
+				return m, nil
+			}
+			m.mode = ModeNormal
+			m.filterInput.Reset()
+			m.setQuery(q)
+			return m, nil
+		}
+	}
+
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	m.filterErr = ""
+	return m, cmd
+}
+
+// filterView renders the filter prompt.
+func (m *Model) filterView() string {
+	title := termenv.String("Filter Mode").Bold().Underline()
+	view := fmt.Sprintf("%v\n\nInput a query (empty to show all tasks)\n"+
+		"conditions like tag:ops, project:web, prio>=medium, due<=today, created<7d, blocked, ready;\n"+
+		"combine them with and, or, not and parentheses\n\n%s\n", title, m.filterInput.View())
+	if m.filterErr != "" {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.HighPriorityColor))
+		view += "\n" + errStyle.Render(m.filterErr) + "\n"
+	}
+	return view
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestFilterPrompt(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	deploy := tm.AddTask("deploy")
+	tm.AddTask("docs")
+	tm.SetTaskPriority(deploy.ID, task.PriorityHigh)
+	tm.SetTaskTags(deploy.ID, []string{"ops"})
+	m := NewModel(config.DefaultConfig(), tm)
+	m.cursor = 1
+
+	typeText := func(text string) {
+		for _, r := range text {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	typeText("prio>=urgent")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeFilter || !strings.Contains(m.View(), "urgent") {
+		t.Fatalf("expected an invalid query to keep the prompt open with an error, got:\n%s", m.View())
+	}
+
+	m.filterInput.SetValue("")
+	typeText("prio>=medium and tag:ops")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || m.filter != FilterQuery || len(m.taskCache) != 1 {
+		t.Fatalf("expected the query to filter the list, got %d tasks", len(m.taskCache))
+	}
+	if !strings.Contains(m.View(), "(filtered: prio>=medium and tag:ops)") {
+		t.Errorf("expected the query in the title, got:\n%s", m.View())
+	}
+
+	// The filter key leaves a query for all tasks, then cycles the presets
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if m.filter != FilterAll || len(m.taskCache) != 2 {
+		t.Errorf("expected all tasks after a query, got %d", len(m.taskCache))
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if m.filter != FilterNone || len(m.taskCache) != 1 || m.taskCache[0].Name != "docs" {
+		t.Errorf("expected tasks without priority, got %d", len(m.taskCache))
+	}
+}
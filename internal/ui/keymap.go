@@ -1,14 +1,343 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"github.com/charmbracelet/bubbles/key"
 
-// FullHelp returns the full help view for all key bindings.
+	"github.com/voioo/td/internal/config"
+)
+
+// KeyMap defines the key bindings for the UI.
+type KeyMap struct {
+	Add      key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Delete   key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	Edit     key.Binding
+	Enter    key.Binding
+	ListType key.Binding
+	Escape   key.Binding
+	Help     key.Binding
+	Quit     key.Binding
+	Filter   key.Binding
+	Undo     key.Binding
+	Redo     key.Binding
+	// New shortcuts
+	PriorityNone   key.Binding
+	PriorityLow    key.Binding
+	PriorityMedium key.Binding
+	PriorityHigh   key.Binding
+	Home           key.Binding
+	End            key.Binding
+	ClearCompleted key.Binding
+	BlockedBy      key.Binding
+	Recurrence     key.Binding
+	EditNotes      key.Binding
+	ToggleDetail   key.Binding
+	PageUp         key.Binding
+	PageDown       key.Binding
+	HalfPageUp     key.Binding
+	HalfPageDown   key.Binding
+	Search         key.Binding
+	NextMatch      key.Binding
+	PrevMatch      key.Binding
+	ToggleMark     key.Binding
+	VisualMode     key.Binding
+	SelectAll      key.Binding
+	EditTags       key.Binding
+	UndoHistory    key.Binding
+	MoveUp         key.Binding
+	MoveDown       key.Binding
+	Sort           key.Binding
+	ReverseSort    key.Binding
+	Group          key.Binding
+	EditProject    key.Binding
+	FilterQuery    key.Binding
+	Views          key.Binding
+	ViewShortcut   key.Binding
+	NextField      key.Binding
+	PrevField      key.Binding
+	Board          key.Binding
+	BoardLayout    key.Binding
+	MoveLeft       key.Binding
+	MoveRight      key.Binding
+	SetStatus      key.Binding
+	Agenda         key.Binding
+	Calendar       key.Binding
+	Stats          key.Binding
+	Timer          key.Binding
+	// Shown after saving failed on quit
+	Retry             key.Binding
+	QuitWithoutSaving key.Binding
+}
+
+// newKeyMap creates the key bindings from the configuration.
+func newKeyMap(cfg *config.Config) KeyMap {
+	return KeyMap{
+		Add: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Add),
+			key.WithHelp(cfg.KeyMap.Add, "add new task"),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Delete),
+			key.WithHelp(cfg.KeyMap.Delete, "delete task"),
+		),
+		Enter: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Enter),
+			key.WithHelp(cfg.KeyMap.Enter, "save"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Up, "k"),
+			key.WithHelp("↑/k", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Down, "j"),
+			key.WithHelp("↓/j", "move down"),
+		),
+		Left: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Left, "h"),
+			key.WithHelp("←/h", "move left"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Right, "l"),
+			key.WithHelp("→/l", "move right"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit task"),
+		),
+		ListType: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.ListType, "tab"),
+			key.WithHelp("t/tab", "list type"),
+		),
+		Escape: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Escape),
+			key.WithHelp(cfg.KeyMap.Escape, "back/cancel"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Help),
+			key.WithHelp(cfg.KeyMap.Help, "all keys"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Quit, "ctrl+c"),
+			key.WithHelp(cfg.KeyMap.Quit, "quit"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Filter),
+			key.WithHelp(cfg.KeyMap.Filter, "filter by priority"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Undo),
+			key.WithHelp(cfg.KeyMap.Undo, "undo"),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys(cfg.KeyMap.Redo),
+			key.WithHelp(cfg.KeyMap.Redo, "redo"),
+		),
+		PriorityNone: key.NewBinding(
+			key.WithKeys("1"),
+			key.WithHelp("1", "set no priority"),
+		),
+		PriorityLow: key.NewBinding(
+			key.WithKeys("2"),
+			key.WithHelp("2", "set low priority"),
+		),
+		PriorityMedium: key.NewBinding(
+			key.WithKeys("3"),
+			key.WithHelp("3", "set medium priority"),
+		),
+		PriorityHigh: key.NewBinding(
+			key.WithKeys("4"),
+			key.WithHelp("4", "set high priority"),
+		),
+		Home: key.NewBinding(
+			key.WithKeys("home", "g"),
+			key.WithHelp("home/g", "go to top"),
+		),
+		End: key.NewBinding(
+			key.WithKeys("end", "G"),
+			key.WithHelp("end/G", "go to bottom"),
+		),
+		ClearCompleted: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "clear completed tasks"),
+		),
+		BlockedBy: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "set blocked by"),
+		),
+		Recurrence: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "set recurrence"),
+		),
+		EditNotes: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "edit notes in $EDITOR"),
+		),
+		ToggleDetail: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "toggle details"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+b"),
+			key.WithHelp("pgup/ctrl+b", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+f"),
+			key.WithHelp("pgdn/ctrl+f", "page down"),
+		),
+		HalfPageUp: key.NewBinding(
+			key.WithKeys("{"),
+			key.WithHelp("{", "half page up"),
+		),
+		HalfPageDown: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}", "half page down"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match"),
+		),
+		ToggleMark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark task"),
+		),
+		VisualMode: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "mark range"),
+		),
+		SelectAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "mark all"),
+		),
+		EditTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "edit tags"),
+		),
+		SetStatus: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "set status"),
+		),
+		Timer: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "start/stop timer"),
+		),
+		EditProject: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "set project"),
+		),
+		FilterQuery: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "filter by query"),
+		),
+		Views: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "views"),
+		),
+		ViewShortcut: key.NewBinding(
+			key.WithKeys("alt+0", "alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9"),
+			key.WithHelp("alt+1-9", "switch view"),
+		),
+		NextField: key.NewBinding(
+			key.WithKeys("tab", "down"),
+			key.WithHelp("tab", "next field"),
+		),
+		PrevField: key.NewBinding(
+			key.WithKeys("shift+tab", "up"),
+			key.WithHelp("shift+tab", "previous field"),
+		),
+		Board: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "board"),
+		),
+		Agenda: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "agenda"),
+		),
+		Calendar: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "calendar"),
+		),
+		Stats: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "statistics"),
+		),
+		BoardLayout: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "board columns"),
+		),
+		MoveLeft: key.NewBinding(
+			key.WithKeys("H", "shift+left"),
+			key.WithHelp("H", "move card left"),
+		),
+		MoveRight: key.NewBinding(
+			key.WithKeys("L", "shift+right"),
+			key.WithHelp("L", "move card right"),
+		),
+		UndoHistory: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "undo history"),
+		),
+		MoveUp: key.NewBinding(
+			key.WithKeys("K", "shift+up"),
+			key.WithHelp("K", "move task up"),
+		),
+		MoveDown: key.NewBinding(
+			key.WithKeys("J", "shift+down"),
+			key.WithHelp("J", "move task down"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "change sort order"),
+		),
+		ReverseSort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "reverse order"),
+		),
+		Group: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "group by"),
+		),
+		Retry: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "retry"),
+		),
+		QuitWithoutSaving: key.NewBinding(
+			key.WithKeys("Q"),
+			key.WithHelp("Q", "quit without saving"),
+		),
+	}
+}
+
+// helpText returns the key and description of a binding, e.g. "r retry".
+func helpText(b key.Binding) string {
+	return b.Help().Key + " " + b.Help().Desc
+}
+
+// ShortHelp returns the key bindings shown below the task list. The usage
+// screen behind the help key lists the others.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Add, k.Edit, k.ListType, k.Help, k.Quit}
+}
+
+// FullHelp returns all key bindings in columns of similar length.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Add, k.Delete, k.Up, k.Down, k.Left, k.Right, k.Edit},
-		{k.ListType, k.Filter, k.Escape},
-		{k.Help, k.Quit, k.Undo, k.Redo},
-		{k.PriorityNone, k.PriorityLow, k.PriorityMedium, k.PriorityHigh},
-		{k.Home, k.End, k.ClearCompleted},
+		{k.Add, k.Delete, k.Edit, k.EditNotes, k.EditTags, k.EditProject, k.SetStatus, k.Timer},
+		{k.Up, k.Down, k.Left, k.Right, k.Home, k.End, k.PageUp, k.PageDown},
+		{k.ListType, k.Board, k.Agenda, k.Calendar, k.Stats, k.ToggleDetail, k.Search, k.NextMatch},
+		{k.PrevMatch, k.Filter, k.FilterQuery, k.Views, k.Sort, k.ReverseSort, k.Group},
+		{k.HalfPageUp, k.HalfPageDown, k.MoveUp, k.MoveDown, k.BlockedBy, k.Recurrence, k.ClearCompleted},
+		{k.PriorityNone, k.PriorityLow, k.PriorityMedium, k.PriorityHigh, k.ToggleMark, k.VisualMode, k.SelectAll, k.Escape},
+		{k.Help, k.Quit, k.Undo, k.Redo, k.UndoHistory},
 	}
 }
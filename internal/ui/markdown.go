@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Markdown styles used when rendering task notes
+var (
+	markdownBoldStyle   = lipgloss.NewStyle().Bold(true)
+	markdownItalicStyle = lipgloss.NewStyle().Italic(true)
+	markdownCodeStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#90CAF9"))
+	markdownQuoteStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#999999")).Italic(true)
+	markdownRuleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+)
+
+// Inline markdown patterns, applied in order
+var (
+	markdownCodePattern   = regexp.MustCompile("`([^`]+)`")
+	markdownBoldPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalicPattern = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// renderMarkdown renders a small subset of markdown (headings, lists, quotes,
+// code blocks, rules and inline emphasis) for display in the terminal.
+// Lines are wrapped to width when it is positive.
+func renderMarkdown(text string, width int, headingStyle lipgloss.Style) string {
+	var lines []string
+	inCodeBlock := false
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			lines = append(lines, markdownCodeStyle.Render("  "+line))
+			continue
+		}
+
+		var rendered string
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			rendered = headingStyle.Bold(true).Render(heading)
+		case trimmed == "---" || trimmed == "***":
+			ruleWidth := width
+			if ruleWidth <= 0 {
+				ruleWidth = 20
+			}
+			rendered = markdownRuleStyle.Render(strings.Repeat("─", ruleWidth))
+		case strings.HasPrefix(trimmed, "> "):
+			rendered = markdownQuoteStyle.Render("│ " + renderInlineMarkdown(trimmed[2:]))
+		case strings.HasPrefix(trimmed, "- [ ] "):
+			rendered = wrapIndented("☐ ", renderInlineMarkdown(trimmed[6:]), width)
+		case strings.HasPrefix(trimmed, "- [x] "), strings.HasPrefix(trimmed, "- [X] "):
+			rendered = wrapIndented("☑ ", renderInlineMarkdown(trimmed[6:]), width)
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			indent := strings.Repeat(" ", len(line)-len(strings.TrimLeft(line, " ")))
+			rendered = wrapIndented(indent+"• ", renderInlineMarkdown(trimmed[2:]), width)
+		default:
+			rendered = wrapIndented("", renderInlineMarkdown(trimmed), width)
+		}
+		lines = append(lines, rendered)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderInlineMarkdown styles inline code, bold and italic spans.
+func renderInlineMarkdown(text string) string {
+	text = markdownCodePattern.ReplaceAllStringFunc(text, func(match string) string {
+		return markdownCodeStyle.Render(strings.Trim(match, "`"))
+	})
+	text = markdownBoldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return markdownBoldStyle.Render(match[2 : len(match)-2])
+	})
+	text = markdownItalicPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return markdownItalicStyle.Render(match[1 : len(match)-1])
+	})
+	return text
+}
+
+// wrapIndented wraps text to width, indenting continuation lines to align with
+// the text after prefix.
+func wrapIndented(prefix, text string, width int) string {
+	if width <= 0 || lipgloss.Width(prefix+text) <= width {
+		return prefix + text
+	}
+	body := lipgloss.NewStyle().Width(width - lipgloss.Width(prefix)).Render(text)
+	return lipgloss.JoinHorizontal(lipgloss.Top, prefix, body)
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"heading", "# Release plan", "Release plan"},
+		{"bullet", "- tag the release", "• tag the release"},
+		{"checklist", "- [x] write changelog", "☑ write changelog"},
+		{"bold", "this is **important**", "this is important"},
+		{"inline code", "run `make test`", "run make test"},
+		{"quote", "> from the docs", "│ from the docs"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := renderMarkdown(test.input, 0, lipgloss.NewStyle())
+			if !strings.Contains(result, test.expected) {
+				t.Errorf("expected %q to contain %q", result, test.expected)
+			}
+		})
+	}
+
+	t.Run("code block markers are hidden", func(t *testing.T) {
+		result := renderMarkdown("```\ngo test ./...\n```", 0, lipgloss.NewStyle())
+		if strings.Contains(result, "```") {
+			t.Errorf("expected code fences to be removed, got %q", result)
+		}
+		if !strings.Contains(result, "go test ./...") {
+			t.Errorf("expected code block contents, got %q", result)
+		}
+	})
+}
@@ -2,14 +2,17 @@
 package ui
 
 import (
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/help"
-	"github.com/charmbracelet/bubbles/key"
 	input "github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/voioo/td/internal/config"
 	"github.com/voioo/td/internal/logger"
+	"github.com/voioo/td/internal/query"
 	"github.com/voioo/td/internal/storage"
 	"github.com/voioo/td/internal/task"
 )
@@ -23,6 +26,21 @@ const (
 	ModeAdditional
 	ModeEdit
 	ModeHelp
+	ModeBlockedBy
+	ModeRecurrence
+	ModeSearch
+	ModeTags
+	ModeUndoHistory
+	ModeProject
+	ModeViewPicker
+	ModeFilter
+	ModeSaveFailed
+	ModeConfirm
+	ModeBoard
+	ModeTaskStatus
+	ModeAgenda
+	ModeCalendar
+	ModeStats
 )
 
 // FilterMode represents different task filtering modes.
@@ -34,8 +52,23 @@ const (
 	FilterLow
 	FilterMedium
 	FilterHigh
+	FilterReady
+	// FilterQuery shows the tasks matching a query typed at the filter prompt.
+	FilterQuery
+
+	// filterModeCount is the number of filter modes cycled through by the filter key.
+	filterModeCount = 6
 )
 
+// filterQueries are the queries of the filter modes cycled through.
+var filterQueries = map[FilterMode]*query.Query{
+	FilterNone:   query.MustParse("prio:none"),
+	FilterLow:    query.MustParse("prio:low"),
+	FilterMedium: query.MustParse("prio:medium"),
+	FilterHigh:   query.MustParse("prio:high"),
+	FilterReady:  query.MustParse("ready"),
+}
+
 // saveAndQuitMsg is sent when the application should save and quit.
 type saveAndQuitMsg struct{}
 
@@ -51,155 +84,139 @@ type Model struct {
 	keys              KeyMap
 	newTaskNameInput  input.Model
 	editTaskNameInput input.Model
+	editPriorityInput input.Model
+	editTagsInput     input.Model
+	editProjectInput  input.Model
+	editDueInput      input.Model
+	blockedByInput    input.Model
+	recurrenceInput   input.Model
+	searchInput       input.Model
+	filterInput       input.Model
+	tagsInput         input.Model
+	projectInput      input.Model
+	taskStatusInput   input.Model
 
 	// UI state
 	cursor     int
+	offset     int // Index of the first task row shown in the list viewport
 	mode       Mode
 	filter     FilterMode
+	query      *query.Query // Query of the filter, nil to show all tasks
+	filterErr  string       // Why the query typed at the filter prompt is invalid
 	quitting   bool
+	showDetail bool
+	width      int          // Terminal width, zero until the first tea.WindowSizeMsg
+	height     int          // Terminal height, zero until the first tea.WindowSizeMsg
 	taskCache  []*task.Task // Cache for filtered tasks
 	cacheValid bool
-}
 
-// KeyMap defines the key bindings for the UI.
-type KeyMap struct {
-	Add      key.Binding
-	Up       key.Binding
-	Down     key.Binding
-	Delete   key.Binding
-	Left     key.Binding
-	Right    key.Binding
-	Edit     key.Binding
-	Enter    key.Binding
-	ListType key.Binding
-	Escape   key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	Filter   key.Binding
-	Undo     key.Binding
-	Redo     key.Binding
-	// New shortcuts
-	PriorityNone   key.Binding
-	PriorityLow    key.Binding
-	PriorityMedium key.Binding
-	PriorityHigh   key.Binding
-	Home           key.Binding
-	End            key.Binding
-	ClearCompleted key.Binding
+	// List settings
+	groupMode     task.GroupMode
+	taskGroups    []task.TaskGroup // Groups of the cached tasks, in the order of taskCache
+	configPath    string           // Where changed list settings are saved, empty to leave the config alone
+	configChanged bool             // Whether the list settings changed since the config was loaded
+
+	// Saved views
+	views      []savedView
+	viewIndex  int // Index of the active view, -1 for all tasks
+	viewCursor int // Entry of the view picker the cursor is on, 0 for all tasks
+
+	// Edit state
+	editTaskID int       // Task being edited
+	editField  editField // Field of the edit mode the focus is on
+	editErr    string    // Why the edited fields cannot be saved
+
+	// Board state
+	boardLayout boardLayout
+	boardColumn int // Column the focused card is in
+	boardRow    int // Position of the focused card in its column
+
+	// Agenda and calendar state
+	agendaCursor int       // Position of the selected task in the agenda
+	agendaOffset int       // Index of the first agenda line shown
+	calendarDay  time.Time // Day selected in the calendar
+
+	// Confirm dialog, nil unless in ModeConfirm
+	*confirmDialog
+
+	// Status line
+	status    statusMessage
+	statusSeq int // Incremented for every message so only the latest one expires
+	timerSeq  int // Incremented for every chain of timer ticks so only one runs
+
+	// Search state
+	searchQuery    string // Active search query, kept after the prompt is closed for n/N
+	searchOrigin   int    // Cursor position to restore when a search is cancelled
+	promptListMode Mode   // List shown behind a prompt such as search or tag editing, or left for the undo history
+
+	// Undo history state
+	historyCursor int // Position in the undo history the cursor is on
+
+	// Selection state
+	marked       map[int]bool // IDs of the tasks marked for bulk operations
+	visual       bool         // Whether a visual range is being selected
+	visualAnchor int          // Cursor position where the visual range started
 }
 
 // NewModel creates a new UI model with the given configuration and task manager.
 func NewModel(cfg *config.Config, taskManager *task.TaskManager) *Model {
-	// Create key bindings from config
-	keys := KeyMap{
-		Add: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Add),
-			key.WithHelp(cfg.KeyMap.Add, "add new task"),
-		),
-		Delete: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Delete),
-			key.WithHelp(cfg.KeyMap.Delete, "delete task"),
-		),
-		Enter: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Enter),
-			key.WithHelp(cfg.KeyMap.Enter, "save"),
-		),
-		Up: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Up, "k"),
-			key.WithHelp("↑/k", "move up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Down, "j"),
-			key.WithHelp("↓/j", "move down"),
-		),
-		Left: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Left, "h"),
-			key.WithHelp("←/h", "move left"),
-		),
-		Right: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Right, "l"),
-			key.WithHelp("→/l", "move right"),
-		),
-		Edit: key.NewBinding(
-			key.WithKeys("e"),
-			key.WithHelp("e", "edit task"),
-		),
-		ListType: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.ListType, "tab"),
-			key.WithHelp("t/tab", "list type"),
-		),
-		Escape: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Escape),
-			key.WithHelp(cfg.KeyMap.Escape, "back/cancel"),
-		),
-		Help: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Help),
-			key.WithHelp(cfg.KeyMap.Help, "toggle usage"),
-		),
-		Quit: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Quit, "ctrl+c"),
-			key.WithHelp(cfg.KeyMap.Quit, "quit"),
-		),
-		Filter: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Filter),
-			key.WithHelp(cfg.KeyMap.Filter, "filter by priority"),
-		),
-		Undo: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Undo),
-			key.WithHelp(cfg.KeyMap.Undo, "undo"),
-		),
-		Redo: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Redo),
-			key.WithHelp(cfg.KeyMap.Redo, "redo"),
-		),
-		// New shortcuts
-		PriorityNone: key.NewBinding(
-			key.WithKeys("1"),
-			key.WithHelp("1", "set no priority"),
-		),
-		PriorityLow: key.NewBinding(
-			key.WithKeys("2"),
-			key.WithHelp("2", "set low priority"),
-		),
-		PriorityMedium: key.NewBinding(
-			key.WithKeys("3"),
-			key.WithHelp("3", "set medium priority"),
-		),
-		PriorityHigh: key.NewBinding(
-			key.WithKeys("4"),
-			key.WithHelp("4", "set high priority"),
-		),
-		Home: key.NewBinding(
-			key.WithKeys("home", "g"),
-			key.WithHelp("home/g", "go to top"),
-		),
-		End: key.NewBinding(
-			key.WithKeys("end", "G"),
-			key.WithHelp("end/G", "go to bottom"),
-		),
-		ClearCompleted: key.NewBinding(
-			key.WithKeys("C"),
-			key.WithHelp("C", "clear completed tasks"),
-		),
-	}
+	keys := newKeyMap(cfg)
 
 	// Create input models
 	newTaskNameModel := input.New()
 	newTaskNameModel.Placeholder = "New task name..."
 	editTaskNameModel := input.New()
+	editPriorityModel := input.New()
+	editPriorityModel.Placeholder = "none"
+	editTagsModel := input.New()
+	editTagsModel.Placeholder = "e.g. #ops #backend"
+	editProjectModel := input.New()
+	editProjectModel.Placeholder = "e.g. +website"
+	editDueModel := input.New()
+	editDueModel.Placeholder = "e.g. 2026-11-03"
+	blockedByModel := input.New()
+	blockedByModel.Placeholder = "e.g. 3, 7"
+	recurrenceModel := input.New()
+	recurrenceModel.Placeholder = "e.g. daily, 2w, mon,fri, 1m!"
+	searchModel := input.New()
+	searchModel.Prompt = "/"
+	searchModel.Placeholder = "search tasks"
+	filterModel := input.New()
+	filterModel.Placeholder = "e.g. prio>=medium and tag:backend and not blocked"
+	tagsModel := input.New()
+	tagsModel.Placeholder = "e.g. ops backend, or +ops -backend"
+	projectModel := input.New()
+	projectModel.Prompt = "+"
+	projectModel.Placeholder = "project name"
+	taskStatusModel := input.New()
+	taskStatusModel.Placeholder = "todo, in_progress, waiting, blocked, done or cancelled"
 
 	m := &Model{
 		config:            cfg,
 		taskManager:       taskManager,
-		undoManager:       task.NewUndoManager(100),
+		undoManager:       task.NewUndoManager(cfg.UndoLimit),
 		keys:              keys,
 		help:              help.New(),
 		inputStyle:        lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.PrimaryColor)),
 		newTaskNameInput:  newTaskNameModel,
 		editTaskNameInput: editTaskNameModel,
+		editPriorityInput: editPriorityModel,
+		editTagsInput:     editTagsModel,
+		editProjectInput:  editProjectModel,
+		editDueInput:      editDueModel,
+		blockedByInput:    blockedByModel,
+		recurrenceInput:   recurrenceModel,
+		searchInput:       searchModel,
+		filterInput:       filterModel,
+		tagsInput:         tagsModel,
+		projectInput:      projectModel,
+		taskStatusInput:   taskStatusModel,
 		cursor:            0,
 		mode:              ModeNormal,
 		filter:            FilterAll,
+		groupMode:         configGroupMode(cfg.GroupBy),
+		views:             loadViews(cfg.Views),
+		viewIndex:         -1,
 		taskCache:         []*task.Task{},
 		cacheValid:        false,
 	}
@@ -213,102 +230,12 @@ func NewModel(cfg *config.Config, taskManager *task.TaskManager) *Model {
 // NewTestModel creates a minimal UI model for testing purposes.
 // It skips initializing Bubble Tea input components that require a TTY.
 func NewTestModel(cfg *config.Config, taskManager *task.TaskManager) (*Model, error) {
-	// Create key bindings from config
-	keys := KeyMap{
-		Add: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Add),
-			key.WithHelp(cfg.KeyMap.Add, "add new task"),
-		),
-		Delete: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Delete),
-			key.WithHelp(cfg.KeyMap.Delete, "delete task"),
-		),
-		Enter: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Enter),
-			key.WithHelp(cfg.KeyMap.Enter, "save"),
-		),
-		Up: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Up, "k"),
-			key.WithHelp("↑/k", "move up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Down, "j"),
-			key.WithHelp("↓/j", "move down"),
-		),
-		Left: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Left, "h"),
-			key.WithHelp("←/h", "move left"),
-		),
-		Right: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Right, "l"),
-			key.WithHelp("→/l", "move right"),
-		),
-		Edit: key.NewBinding(
-			key.WithKeys("e"),
-			key.WithHelp("e", "edit task"),
-		),
-		ListType: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.ListType, "tab"),
-			key.WithHelp("t/tab", "list type"),
-		),
-		Escape: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Escape),
-			key.WithHelp(cfg.KeyMap.Escape, "back/cancel"),
-		),
-		Help: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Help),
-			key.WithHelp(cfg.KeyMap.Help, "toggle usage"),
-		),
-		Quit: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Quit, "ctrl+c"),
-			key.WithHelp(cfg.KeyMap.Quit, "quit"),
-		),
-		Filter: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Filter),
-			key.WithHelp(cfg.KeyMap.Filter, "filter by priority"),
-		),
-		Undo: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Undo),
-			key.WithHelp(cfg.KeyMap.Undo, "undo"),
-		),
-		Redo: key.NewBinding(
-			key.WithKeys(cfg.KeyMap.Redo),
-			key.WithHelp(cfg.KeyMap.Redo, "redo"),
-		),
-		PriorityNone: key.NewBinding(
-			key.WithKeys("1"),
-			key.WithHelp("1", "set no priority"),
-		),
-		PriorityLow: key.NewBinding(
-			key.WithKeys("2"),
-			key.WithHelp("2", "set low priority"),
-		),
-		PriorityMedium: key.NewBinding(
-			key.WithKeys("3"),
-			key.WithHelp("3", "set medium priority"),
-		),
-		PriorityHigh: key.NewBinding(
-			key.WithKeys("4"),
-			key.WithHelp("4", "set high priority"),
-		),
-		Home: key.NewBinding(
-			key.WithKeys("home", "g"),
-			key.WithHelp("home/g", "go to top"),
-		),
-		End: key.NewBinding(
-			key.WithKeys("end", "G"),
-			key.WithHelp("end/G", "go to bottom"),
-		),
-		ClearCompleted: key.NewBinding(
-			key.WithKeys("C"),
-			key.WithHelp("C", "clear completed tasks"),
-		),
-	}
+	keys := newKeyMap(cfg)
 
 	m := &Model{
 		config:      cfg,
 		taskManager: taskManager,
-		undoManager: task.NewUndoManager(100),
+		undoManager: task.NewUndoManager(cfg.UndoLimit),
 		keys:        keys,
 		help:        help.New(),
 		inputStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color(cfg.Theme.PrimaryColor)),
@@ -316,6 +243,9 @@ func NewTestModel(cfg *config.Config, taskManager *task.TaskManager) (*Model, er
 		cursor:     0,
 		mode:       ModeNormal,
 		filter:     FilterAll,
+		groupMode:  configGroupMode(cfg.GroupBy),
+		views:      loadViews(cfg.Views),
+		viewIndex:  -1,
 		taskCache:  []*task.Task{},
 		cacheValid: false,
 	}
@@ -326,30 +256,40 @@ func NewTestModel(cfg *config.Config, taskManager *task.TaskManager) (*Model, er
 	return m, nil
 }
 
-// saveAndQuitCmd returns a command that saves tasks and then quits.
+// saveAndQuitCmd returns a command that saves tasks and then quits. When the
+// tasks cannot be saved the application stays open, see saveFailed.
 func (m *Model) saveAndQuitCmd() tea.Cmd {
-	return tea.Sequence(
-		func() tea.Msg {
-			logger.Info("Saving tasks before quit",
-				logger.F("active_tasks", len(m.taskManager.GetTasks())),
-				logger.F("done_tasks", len(m.taskManager.GetDoneTasks())))
-
-			repo := storage.NewRepository(m.config.DataFile)
-			err := repo.SaveTasks(m.taskManager.GetTasks(), m.taskManager.GetDoneTasks())
-			if err != nil {
-				logger.Error("Failed to save tasks", logger.F("error", err))
-			} else {
-				logger.Info("Tasks saved successfully")
+	return func() tea.Msg {
+		logger.Info("Saving tasks before quit",
+			logger.F("active_tasks", len(m.taskManager.GetTasks())),
+			logger.F("done_tasks", len(m.taskManager.GetDoneTasks())))
+
+		repo := storage.NewRepository(m.config.DataFile)
+		err := repo.SaveTasks(m.taskManager.GetTasks(), m.taskManager.GetDoneTasks())
+		if err != nil {
+			logger.Error("Failed to save tasks", logger.F("error", err))
+			return saveFailedMsg{err: err}
+		}
+		logger.Info("Tasks saved successfully")
+
+		// Keep the undo history for the next session
+		if err := repo.SaveHistory(m.undoManager.Export()); err != nil {
+			logger.Error("Failed to save undo history", logger.F("error", err))
+		}
+
+		// Remember the sort order, grouping and skipped confirmations
+		if m.configChanged && m.configPath != "" {
+			if err := m.config.SaveSettings(m.configPath); err != nil {
+				logger.Error("Failed to save configuration", logger.F("error", err))
 			}
-			return saveAndQuitMsg{}
-		},
-		tea.Quit,
-	)
+		}
+		return saveAndQuitMsg{}
+	}
 }
 
 // Init initializes the Bubble Tea model.
 func (m *Model) Init() tea.Cmd {
-	return nil
+	return m.tickTimer()
 }
 
 // Update handles UI updates based on messages.
@@ -358,6 +298,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case saveAndQuitMsg:
 		m.quitting = true
 		return m, tea.Quit
+	case saveFailedMsg:
+		m.saveFailed(msg.err)
+		return m, nil
+	case clearStatusMsg:
+		if msg.seq == m.statusSeq {
+			m.status = statusMessage{}
+		}
+		return m, nil
+	case timerTickMsg:
+		if msg.seq == m.timerSeq {
+			return m, m.tickTimer()
+		}
+		return m, nil
+	case notesEditedMsg:
+		return m.handleNotesEdited(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		return m, nil
 	default:
 		switch m.mode {
 		case ModeNormal:
@@ -370,16 +330,55 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.editTaskUpdate(msg)
 		case ModeHelp:
 			return m.helpUpdate(msg)
+		case ModeBlockedBy:
+			return m.blockedByUpdate(msg)
+		case ModeRecurrence:
+			return m.recurrenceUpdate(msg)
+		case ModeSearch:
+			return m.searchUpdate(msg)
+		case ModeTags:
+			return m.tagsUpdate(msg)
+		case ModeUndoHistory:
+			return m.undoHistoryUpdate(msg)
+		case ModeProject:
+			return m.projectUpdate(msg)
+		case ModeViewPicker:
+			return m.viewPickerUpdate(msg)
+		case ModeFilter:
+			return m.filterUpdate(msg)
+		case ModeSaveFailed:
+			return m.saveFailedUpdate(msg)
+		case ModeConfirm:
+			return m.confirmUpdate(msg)
+		case ModeBoard:
+			return m.boardUpdate(msg)
+		case ModeTaskStatus:
+			return m.taskStatusUpdate(msg)
+		case ModeAgenda:
+			return m.agendaUpdate(msg)
+		case ModeCalendar:
+			return m.calendarUpdate(msg)
+		case ModeStats:
+			return m.statsUpdate(msg)
 		default:
 			return m, nil
 		}
 	}
 }
 
-// View renders the current UI state.
+// View renders the current UI state with the status line at the bottom.
 func (m *Model) View() string {
+	view := m.modeView()
+	if line := m.statusLine(); line != "" && !m.quitting {
+		view = strings.TrimSuffix(view, "\n") + "\n" + line + "\n"
+	}
+	return view
+}
+
+// modeView renders the current mode.
+func (m *Model) modeView() string {
 	switch m.mode {
-	case ModeNormal, ModeDoneTaskList:
+	case ModeNormal, ModeDoneTaskList, ModeSearch, ModeSaveFailed:
 		return m.normalView()
 	case ModeAdditional:
 		return m.addingTaskView()
@@ -387,6 +386,32 @@ func (m *Model) View() string {
 		return m.editTaskView()
 	case ModeHelp:
 		return m.helpView()
+	case ModeBlockedBy:
+		return m.blockedByView()
+	case ModeRecurrence:
+		return m.recurrenceView()
+	case ModeTags:
+		return m.tagsView()
+	case ModeUndoHistory:
+		return m.undoHistoryView()
+	case ModeProject:
+		return m.projectView()
+	case ModeViewPicker:
+		return m.viewPickerView()
+	case ModeFilter:
+		return m.filterView()
+	case ModeConfirm:
+		return m.confirmView()
+	case ModeBoard:
+		return m.boardView()
+	case ModeTaskStatus:
+		return m.taskStatusView()
+	case ModeAgenda:
+		return m.agendaView()
+	case ModeCalendar:
+		return m.calendarView()
+	case ModeStats:
+		return m.statsView()
 	}
 	return ""
 }
@@ -396,6 +421,25 @@ func (m *Model) GetTaskManager() *task.TaskManager {
 	return m.taskManager
 }
 
+// GetUndoManager returns the undo manager.
+func (m *Model) GetUndoManager() *task.UndoManager {
+	return m.undoManager
+}
+
+// SetUndoManager replaces the undo manager, e.g. with one restored from a
+// previous session.
+func (m *Model) SetUndoManager(undoManager *task.UndoManager) {
+	m.undoManager = undoManager
+}
+
+// SetConfigPath sets the file the sort order, grouping and skipped
+// confirmations are saved to when they were changed, see
+// config.Config.SaveSettings. Without a path the configuration file is left
+// alone.
+func (m *Model) SetConfigPath(path string) {
+	m.configPath = path
+}
+
 // GetConfig returns the configuration.
 func (m *Model) GetConfig() *config.Config {
 	return m.config
@@ -407,19 +451,24 @@ func (m *Model) updateTaskCache() {
 		return
 	}
 
-	tasks := m.taskManager.GetTasks()
-	if m.filter == FilterAll {
-		m.taskCache = tasks
-	} else {
-		m.taskCache = []*task.Task{}
-		for _, task := range tasks {
-			if filterToPriority(m.filter) == task.Priority {
-				m.taskCache = append(m.taskCache, task)
-			}
-		}
+	env := query.Env{Tasks: m.taskManager, Now: time.Now()}
+	m.taskCache = m.taskManager.GetTasks()
+	if view := m.activeView(); view != nil {
+		m.taskCache = view.filter.Filter(m.taskCache, env)
+	}
+	if m.query != nil {
+		m.taskCache = m.query.Filter(m.taskCache, env)
 	}
 
-	task.SortTasksByPriority(m.taskCache)
+	m.taskManager.SortTasks(m.taskCache)
+	m.taskGroups = task.GroupTasks(m.taskCache, m.groupMode, env.Now)
+	if len(m.taskGroups) > 1 {
+		// Keep the cursor order in line with the groups shown
+		m.taskCache = make([]*task.Task, 0, len(m.taskCache))
+		for _, group := range m.taskGroups {
+			m.taskCache = append(m.taskCache, group.Tasks...)
+		}
+	}
 	m.cacheValid = true
 }
 
@@ -437,6 +486,18 @@ func (m *Model) getCurrentTask() *task.Task {
 	return nil
 }
 
+// selectedTask returns the task under the cursor in the active or completed list.
+func (m *Model) selectedTask() *task.Task {
+	if m.listMode() == ModeDoneTaskList {
+		doneTasks := m.taskManager.GetDoneTasks()
+		if m.cursor > 0 && m.cursor <= len(doneTasks) {
+			return doneTasks[m.cursor-1]
+		}
+		return nil
+	}
+	return m.getCurrentTask()
+}
+
 // followTask adjusts cursor position to follow a specific task.
 func (m *Model) followTask(taskID int) {
 	m.updateTaskCache()
@@ -453,22 +514,6 @@ func (m *Model) followTask(taskID int) {
 	}
 }
 
-// filterToPriority converts filter mode to priority.
-func filterToPriority(f FilterMode) task.Priority {
-	switch f {
-	case FilterHigh:
-		return task.PriorityHigh
-	case FilterMedium:
-		return task.PriorityMedium
-	case FilterLow:
-		return task.PriorityLow
-	case FilterNone:
-		return task.PriorityNone
-	default:
-		return task.PriorityNone
-	}
-}
-
 // filterModeName returns a human-readable name for the current filter mode.
 func (m *Model) filterModeName() string {
 	switch m.filter {
@@ -480,6 +525,10 @@ func (m *Model) filterModeName() string {
 		return "medium priority"
 	case FilterHigh:
 		return "high priority"
+	case FilterReady:
+		return "ready to work on"
+	case FilterQuery:
+		return m.query.String()
 	default:
 		return "all"
 	}
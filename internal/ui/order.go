@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"github.com/voioo/td/internal/logger"
+	"github.com/voioo/td/internal/task"
+)
+
+// moveTask moves the task under the cursor delta rows up or down the list by
+// swapping places with its neighbour. In priority order tasks only move within
+// their priority, and in a grouped list only within their group. In the other
+// orders, or in reverse, moving switches to manual order.
+func (m *Model) moveTask(delta int) {
+	m.updateTaskCache()
+	index := m.cursor - 1
+	neighbour := index + delta
+	if index < 0 || index >= len(m.taskCache) || neighbour < 0 || neighbour >= len(m.taskCache) {
+		return
+	}
+
+	t, other := m.taskCache[index], m.taskCache[neighbour]
+	mode := m.taskManager.SortMode()
+	if mode == task.SortPriority && t.Priority != other.Priority {
+		return
+	}
+	if m.groupIndex(t) != m.groupIndex(other) {
+		return
+	}
+	command, err := m.taskManager.MoveTask(t.ID, other.ID)
+	if err != nil {
+		return
+	}
+	if m.taskManager.SortReverse() {
+		// Positions were numbered in the order shown
+		m.taskManager.SetSortReverse(false)
+		if mode == task.SortPriority {
+			m.taskManager.SetSortMode(task.SortManual)
+		}
+	}
+	if mode != task.SortPriority && mode != task.SortManual {
+		m.taskManager.SetSortMode(task.SortManual)
+	}
+	m.rememberListSettings()
+	m.undoManager.PushUndo(command)
+	m.invalidateCache()
+	m.followTask(t.ID)
+}
+
+// groupIndex returns the index of the group the task is listed under.
+func (m *Model) groupIndex(t *task.Task) int {
+	for i, group := range m.taskGroups {
+		for _, grouped := range group.Tasks {
+			if grouped == t {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// cycleSortMode switches to the next sort order, keeping the cursor on the
+// same task.
+func (m *Model) cycleSortMode() {
+	modes := task.SortModes
+	next := modes[0]
+	for i, mode := range modes {
+		if mode == m.taskManager.SortMode() {
+			next = modes[(i+1)%len(modes)]
+			break
+		}
+	}
+	m.changeListSettings(func() { m.taskManager.SetSortMode(next) })
+}
+
+// reverseSort flips the direction of the sort order.
+func (m *Model) reverseSort() {
+	m.changeListSettings(func() { m.taskManager.SetSortReverse(!m.taskManager.SortReverse()) })
+}
+
+// cycleGroupMode switches to the next way of grouping the list.
+func (m *Model) cycleGroupMode() {
+	modes := task.GroupModes
+	next := modes[0]
+	for i, mode := range modes {
+		if mode == m.groupMode {
+			next = modes[(i+1)%len(modes)]
+			break
+		}
+	}
+	m.changeListSettings(func() { m.groupMode = next })
+}
+
+// changeListSettings applies a change to the order of the list, keeping the
+// cursor on the same task, and remembers the new settings.
+func (m *Model) changeListSettings(change func()) {
+	current := m.getCurrentTask()
+	change()
+	m.rememberListSettings()
+	m.invalidateCache()
+	if current != nil {
+		m.followTask(current.ID)
+	}
+}
+
+// rememberListSettings stores the sort order and grouping in the config, to
+// be saved on quit. Changes made while a view is shown only last as long as
+// the view.
+func (m *Model) rememberListSettings() {
+	if m.activeView() != nil {
+		return
+	}
+	m.config.SortMode = string(m.taskManager.SortMode())
+	m.config.SortReverse = m.taskManager.SortReverse()
+	m.config.GroupBy = string(m.groupMode)
+	m.configChanged = true
+}
+
+// sortOrderName describes the sort order for the title, or returns an empty
+// string for the default priority order.
+func (m *Model) sortOrderName() string {
+	mode := m.taskManager.SortMode()
+	if !m.taskManager.SortReverse() {
+		if mode == task.SortPriority {
+			return ""
+		}
+		return string(mode)
+	}
+	return string(mode) + ", reversed"
+}
+
+// configGroupMode returns the grouping set in the config, or no grouping when
+// it is not known.
+func configGroupMode(name string) task.GroupMode {
+	mode, err := task.ParseGroupMode(name)
+	if err != nil {
+		logger.Warn("Invalid group mode, not grouping", logger.F("error", err))
+		return task.GroupNone
+	}
+	return mode
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/voioo/td/internal/task"
+)
+
+func TestMoveTask(t *testing.T) {
+	m := newSelectionModel(t, "low", "high one", "high two")
+	for _, tk := range m.taskManager.GetTasks() {
+		if tk.Name != "low" {
+			m.taskManager.SetTaskPriority(tk.ID, task.PriorityHigh)
+		}
+	}
+	m.invalidateCache()
+	m.updateTaskCache()
+
+	// "high two", "high one", "low": the last high task moves down within its priority only
+	m.cursor = 2
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	if m.cursor != 2 || m.taskCache[2].Name != "low" {
+		t.Errorf("expected no move across priorities, got cursor %d", m.cursor)
+	}
+
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	if m.cursor != 1 || m.taskCache[0].Name != "high one" {
+		t.Errorf("expected high one to move up with the cursor, got cursor %d", m.cursor)
+	}
+
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyCtrlU})
+	m.invalidateCache()
+	m.updateTaskCache()
+	if m.taskCache[0].Name != "high two" {
+		t.Errorf("expected undo to restore the order, got %q first", m.taskCache[0].Name)
+	}
+}
+
+func TestCycleSortMode(t *testing.T) {
+	m := newSelectionModel(t, "b", "c", "a")
+	m.cursor = 1 // "a", the newest task
+
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.taskManager.SortMode() != task.SortManual {
+		t.Errorf("expected manual order after priority, got %s", m.taskManager.SortMode())
+	}
+	for m.taskManager.SortMode() != task.SortName {
+		m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	}
+	if cur := m.getCurrentTask(); cur == nil || cur.Name != "a" || m.cursor != 1 {
+		t.Errorf("expected the cursor to follow the task, got cursor %d", m.cursor)
+	}
+
+	// Moving in name order switches to manual order
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	if m.taskManager.SortMode() != task.SortManual {
+		t.Errorf("expected moving to switch to manual order, got %s", m.taskManager.SortMode())
+	}
+	if m.cursor != 2 || m.getCurrentTask().Name != "a" {
+		t.Errorf("expected a to move below b, got cursor %d", m.cursor)
+	}
+}
+
+func TestGroupedList(t *testing.T) {
+	m := newSelectionModel(t, "ship", "plan", "fix")
+	for _, tk := range m.taskManager.GetTasks() {
+		if tk.Name != "plan" {
+			m.taskManager.SetTaskPriority(tk.ID, task.PriorityHigh)
+		}
+	}
+	m.invalidateCache()
+	m.updateTaskCache()
+	m.cursor = 3 // "plan"
+
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if m.groupMode != task.GroupPriority || m.config.GroupBy != "priority" || !m.configChanged {
+		t.Errorf("expected grouping by priority to be remembered, got %q", m.config.GroupBy)
+	}
+	view := m.View()
+	high, none := strings.Index(view, "High priority"), strings.Index(view, "No priority")
+	if high < 0 || none < high || !strings.Contains(view, "(grouped: priority)") {
+		t.Errorf("expected group headers in the view, got:\n%s", view)
+	}
+	if cur := m.getCurrentTask(); cur == nil || cur.Name != "plan" {
+		t.Errorf("expected the cursor to stay on plan, got %v", cur)
+	}
+
+	// Tasks move within their group only
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	if m.cursor != 3 {
+		t.Errorf("expected no move across groups, got cursor %d", m.cursor)
+	}
+
+	// Headers count as rows when scrolling
+	m.height = 3 + listChromeLines + lipgloss.Height(m.listHelpView())
+	m.cursor = 1
+	view = m.View()
+	if !strings.Contains(view, "High priority") || strings.Contains(view, "No priority") {
+		t.Errorf("expected only the first group to fit, got:\n%s", view)
+	}
+	m.cursor = 3
+	view = m.View()
+	if !strings.Contains(view, "No priority") || !strings.Contains(view, "plan") {
+		t.Errorf("expected the second group header to scroll into view, got:\n%s", view)
+	}
+}
+
+func TestReverseSort(t *testing.T) {
+	m := newSelectionModel(t, "b", "c", "a")
+	for m.taskManager.SortMode() != task.SortName {
+		m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	}
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if m.taskCache[0].Name != "c" || !m.config.SortReverse || m.config.SortMode != "name" {
+		t.Errorf("expected reverse name order to be remembered, got %q first", m.taskCache[0].Name)
+	}
+	if !strings.Contains(m.View(), "(sorted: name, reversed)") {
+		t.Error("expected the reversed order in the title")
+	}
+
+	// Moving keeps the order shown and switches to manual order
+	m.cursor = 1
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	if m.taskManager.SortMode() != task.SortManual || m.taskManager.SortReverse() {
+		t.Errorf("expected moving to switch to manual order, got %s", m.sortOrderName())
+	}
+	if got := []string{m.taskCache[0].Name, m.taskCache[1].Name, m.taskCache[2].Name}; got[0] != "b" || got[1] != "c" || got[2] != "a" {
+		t.Errorf("expected b c a, got %v", got)
+	}
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// Fuzzy match scoring
+const (
+	scoreMatch       = 1
+	scoreConsecutive = 4
+	scoreWordStart   = 6
+	scoreSubstring   = 10
+)
+
+// searchHighlightStyle renders the runes of a task name matched by the search query.
+var searchHighlightStyle = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(lipgloss.Color("#FFD54F"))
+
+// fuzzyMatch reports whether all runes of pattern appear in text in order,
+// ignoring case. It returns a score, higher for contiguous matches and matches
+// at word starts, and the rune positions of text that matched.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	needle := []rune(strings.ToLower(strings.TrimSpace(pattern)))
+	haystack := []rune(strings.ToLower(text))
+	if len(needle) == 0 {
+		return 0, nil, false
+	}
+
+	// Prefer a contiguous occurrence, which is what the user most likely means
+	if start := runeIndex(haystack, needle); start >= 0 {
+		positions := make([]int, len(needle))
+		for i := range needle {
+			positions[i] = start + i
+		}
+		return scorePositions(haystack, positions) + scoreSubstring, positions, true
+	}
+
+	positions := make([]int, 0, len(needle))
+	next := 0
+	for i, r := range haystack {
+		if next < len(needle) && r == needle[next] {
+			positions = append(positions, i)
+			next++
+		}
+	}
+	if next < len(needle) {
+		return 0, nil, false
+	}
+	return scorePositions(haystack, positions), positions, true
+}
+
+// runeIndex returns the index of the first occurrence of needle in haystack, or -1.
+func runeIndex(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		found := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
+
+// scorePositions scores matched rune positions within text.
+func scorePositions(text []rune, positions []int) int {
+	score := 0
+	for i, pos := range positions {
+		score += scoreMatch
+		if i > 0 && positions[i-1] == pos-1 {
+			score += scoreConsecutive
+		}
+		if pos == 0 || !unicode.IsLetter(text[pos-1]) && !unicode.IsDigit(text[pos-1]) {
+			score += scoreWordStart
+		}
+	}
+	return score
+}
+
+// matchTask fuzzy-matches the query against a task's name, tags and notes.
+// The returned positions refer to the name and are empty when only the tags or
+// notes matched.
+func matchTask(query string, t *task.Task) (int, []int, bool) {
+	if score, positions, ok := fuzzyMatch(query, t.Name); ok {
+		return score, positions, true
+	}
+	for _, tag := range t.Tags {
+		if score, _, ok := fuzzyMatch(task.NormalizeTag(query), tag); ok {
+			return score, nil, true
+		}
+	}
+	// Notes only count when the query appears verbatim, fuzzy matching long
+	// text would match almost anything
+	if t.Notes != "" && strings.Contains(strings.ToLower(t.Notes), strings.ToLower(strings.TrimSpace(query))) {
+		return scoreMatch, nil, true
+	}
+	return 0, nil, false
+}
+
+// highlightRunes renders text with the runes at positions highlighted and the
+// rest in base.
+func highlightRunes(text string, positions []int, base lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var sb strings.Builder
+	var run []rune
+	runMatched := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runMatched {
+			sb.WriteString(searchHighlightStyle.Render(string(run)))
+		} else {
+			sb.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != runMatched {
+			flush()
+			runMatched = matched[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+	return sb.String()
+}
+
+// startSearch opens the search prompt over the list currently shown.
+func (m *Model) startSearch() tea.Cmd {
+	if len(m.listTasks()) == 0 {
+		return nil
+	}
+	m.promptListMode = m.mode
+	m.searchOrigin = m.cursor
+	m.searchQuery = ""
+	m.searchInput.SetValue("")
+	m.mode = ModeSearch
+	return m.searchInput.Focus()
+}
+
+// listMode returns the list the user is looking at, also while a prompt such
+// as search is open over it.
+func (m *Model) listMode() Mode {
+	switch m.mode {
+	case ModeSearch, ModeTags, ModeSaveFailed:
+		return m.promptListMode
+	}
+	return m.mode
+}
+
+// listTasks returns the tasks of the list the user is looking at, in display order.
+func (m *Model) listTasks() []*task.Task {
+	if m.listMode() == ModeDoneTaskList {
+		return m.taskManager.GetDoneTasks()
+	}
+	m.updateTaskCache()
+	return m.taskCache
+}
+
+// searchMatches returns the list indices of the tasks matching the search query.
+func (m *Model) searchMatches() []int {
+	if m.searchQuery == "" {
+		return nil
+	}
+	var matches []int
+	for i, t := range m.listTasks() {
+		if _, _, ok := matchTask(m.searchQuery, t); ok {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// jumpToBestMatch moves the cursor to the highest scoring match, preferring
+// the earliest one on ties. The cursor is left alone when nothing matches.
+func (m *Model) jumpToBestMatch() {
+	best, bestScore := -1, 0
+	for i, t := range m.listTasks() {
+		if score, _, ok := matchTask(m.searchQuery, t); ok && (best < 0 || score > bestScore) {
+			best, bestScore = i, score
+		}
+	}
+	if best >= 0 {
+		m.cursor = best + 1
+	}
+}
+
+// jumpToMatch moves the cursor to the next match after it, or the previous one
+// when backwards is set, wrapping around the list.
+func (m *Model) jumpToMatch(backwards bool) {
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		return
+	}
+
+	current := m.cursor - 1
+	if backwards {
+		for i := len(matches) - 1; i >= 0; i-- {
+			if matches[i] < current {
+				m.cursor = matches[i] + 1
+				return
+			}
+		}
+		m.cursor = matches[len(matches)-1] + 1
+		return
+	}
+	for _, index := range matches {
+		if index > current {
+			m.cursor = index + 1
+			return
+		}
+	}
+	m.cursor = matches[0] + 1
+}
+
+// searchLine describes the search prompt or the active query below the list,
+// or returns an empty string when there is no search.
+func (m *Model) searchLine() string {
+	if m.mode != ModeSearch && m.searchQuery == "" {
+		return ""
+	}
+
+	var status string
+	switch matches := len(m.searchMatches()); {
+	case m.searchQuery == "":
+	case matches == 0:
+		status = "no matches"
+	case matches == 1:
+		status = "1 match"
+	default:
+		status = fmt.Sprintf("%d matches", matches)
+	}
+
+	if m.mode == ModeSearch {
+		line := m.searchInput.View()
+		if status != "" {
+			line += "  " + mutedStyle.Render(status)
+		}
+		return line
+	}
+	return mutedStyle.Render("/" + m.searchQuery + "  " + status + " · n/N next/previous, esc to clear")
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		text      string
+		positions []int
+		ok        bool
+	}{
+		{"substring", "ploy", "Deploy API", []int{2, 3, 4, 5}, true},
+		{"case insensitive", "API", "deploy api", []int{7, 8, 9}, true},
+		{"subsequence", "dpa", "Deploy API", []int{0, 2, 7}, true},
+		{"out of order", "apd", "Deploy API", nil, false},
+		{"empty pattern", "", "Deploy API", nil, false},
+		{"unicode", "cafe", "Café run", nil, false},
+		{"unicode runes", "café", "Café run", []int{0, 1, 2, 3}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, positions, ok := fuzzyMatch(test.pattern, test.text)
+			if ok != test.ok {
+				t.Fatalf("expected match %v, got %v", test.ok, ok)
+			}
+			if len(positions) != len(test.positions) {
+				t.Fatalf("expected positions %v, got %v", test.positions, positions)
+			}
+			for i := range positions {
+				if positions[i] != test.positions[i] {
+					t.Errorf("expected positions %v, got %v", test.positions, positions)
+				}
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchScoring(t *testing.T) {
+	contiguous, _, _ := fuzzyMatch("test", "run tests")
+	scattered, _, _ := fuzzyMatch("test", "the best sandwich")
+	if contiguous <= scattered {
+		t.Errorf("expected contiguous match to score higher: %d <= %d", contiguous, scattered)
+	}
+
+	wordStart, _, _ := fuzzyMatch("rd", "review docs")
+	midWord, _, _ := fuzzyMatch("rd", "broad")
+	if wordStart <= midWord {
+		t.Errorf("expected word start match to score higher: %d <= %d", wordStart, midWord)
+	}
+}
+
+func TestMatchTaskNotes(t *testing.T) {
+	withNotes := &task.Task{Name: "Release", Notes: "Remember the changelog"}
+	if _, positions, ok := matchTask("changelog", withNotes); !ok || positions != nil {
+		t.Errorf("expected a notes match without name positions, got %v %v", positions, ok)
+	}
+	if _, _, ok := matchTask("chlg", withNotes); ok {
+		t.Error("expected notes to require the exact text")
+	}
+}
+
+func TestJumpToMatch(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 1)
+	for _, name := range []string{"write report", "deploy", "review report", "lunch"} {
+		tm.AddTask(name)
+	}
+	m, err := NewTestModel(config.DefaultConfig(), tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.searchQuery = "report"
+	m.cursor = 1
+	names := func() string { return m.getCurrentTask().Name }
+
+	m.jumpToMatch(false)
+	first := names()
+	m.jumpToMatch(false)
+	second := names()
+	if first == second || first == "deploy" || second == "lunch" {
+		t.Fatalf("expected to visit both reports, got %q then %q", first, second)
+	}
+	m.jumpToMatch(false)
+	if names() != first {
+		t.Errorf("expected to wrap around to %q, got %q", first, names())
+	}
+	m.jumpToMatch(true)
+	if names() != second {
+		t.Errorf("expected to go back to %q, got %q", second, names())
+	}
+
+	m.searchQuery = "rvw"
+	m.jumpToBestMatch()
+	if names() != "review report" {
+		t.Errorf("expected best match %q, got %q", "review report", names())
+	}
+}
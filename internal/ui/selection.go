@@ -0,0 +1,373 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// isSelected reports whether the task at the given list index is marked or
+// inside the visual range.
+func (m *Model) isSelected(t *task.Task, index int) bool {
+	if m.marked[t.ID] {
+		return true
+	}
+	if !m.visual {
+		return false
+	}
+	from, to := m.visualAnchor-1, m.cursor-1
+	if from > to {
+		from, to = to, from
+	}
+	return index >= from && index <= to
+}
+
+// selectedTasks returns the marked tasks and the tasks in the visual range, in
+// list order. Marked tasks hidden by the current filter are left out.
+func (m *Model) selectedTasks() []*task.Task {
+	if len(m.marked) == 0 && !m.visual {
+		return nil
+	}
+	var selected []*task.Task
+	for i, t := range m.listTasks() {
+		if m.isSelected(t, i) {
+			selected = append(selected, t)
+		}
+	}
+	return selected
+}
+
+// targetTasks returns the tasks an action applies to: the selection if there
+// is one, otherwise the task under the cursor.
+func (m *Model) targetTasks() []*task.Task {
+	if selected := m.selectedTasks(); len(selected) > 0 {
+		return selected
+	}
+	if t := m.selectedTask(); t != nil {
+		return []*task.Task{t}
+	}
+	return nil
+}
+
+// toggleMark marks or unmarks the task under the cursor and moves to the next one.
+func (m *Model) toggleMark() {
+	t := m.selectedTask()
+	if t == nil {
+		return
+	}
+	if m.marked == nil {
+		m.marked = make(map[int]bool)
+	}
+	if m.marked[t.ID] {
+		delete(m.marked, t.ID)
+	} else {
+		m.marked[t.ID] = true
+	}
+	m.moveCursor(1, len(m.listTasks()))
+}
+
+// toggleVisual starts a visual range at the cursor, or marks the tasks in the
+// range when one is already being selected.
+func (m *Model) toggleVisual() {
+	if m.selectedTask() == nil {
+		return
+	}
+	if !m.visual {
+		m.visual = true
+		m.visualAnchor = m.cursor
+		return
+	}
+
+	if m.marked == nil {
+		m.marked = make(map[int]bool)
+	}
+	for _, t := range m.selectedTasks() {
+		m.marked[t.ID] = true
+	}
+	m.visual = false
+}
+
+// toggleSelectAll marks every task in the current list, or unmarks them all
+// when they are already marked.
+func (m *Model) toggleSelectAll() {
+	tasks := m.listTasks()
+	m.visual = false
+	if len(m.marked) > 0 && len(m.selectedTasks()) == len(tasks) {
+		m.marked = nil
+		return
+	}
+	m.marked = make(map[int]bool, len(tasks))
+	for _, t := range tasks {
+		m.marked[t.ID] = true
+	}
+}
+
+// hasSelection reports whether any task is marked or in a visual range.
+func (m *Model) hasSelection() bool {
+	return len(m.marked) > 0 || m.visual
+}
+
+// clearSelection unmarks all tasks and ends the visual range.
+func (m *Model) clearSelection() {
+	m.marked = nil
+	m.visual = false
+}
+
+// clampCursor keeps the cursor within a list of total tasks.
+func (m *Model) clampCursor(total int) {
+	if total == 0 {
+		m.cursor = 0
+	} else if m.cursor > total {
+		m.cursor = total
+	} else if m.cursor < 1 {
+		m.cursor = 1
+	}
+}
+
+// completeTasks completes the given tasks as a single undoable step. Tasks
+// blocked only by other tasks of the batch are completed after their blockers,
+// and tasks that stay blocked are skipped and reported in the status bar.
+func (m *Model) completeTasks(tasks []*task.Task) tea.Cmd {
+	m.undoManager.Begin()
+	remaining := tasks
+	for progress := true; progress && len(remaining) > 0; {
+		progress = false
+		var blocked []*task.Task
+		for _, t := range remaining {
+			if completedTask := m.taskManager.CompleteTask(t.ID); completedTask != nil {
+				m.undoManager.PushUndo(&task.CompleteCommand{TaskID: completedTask.ID, CompletedAt: completedTask.CompletedAt})
+				progress = true
+			} else {
+				blocked = append(blocked, t)
+			}
+		}
+		remaining = blocked
+	}
+	m.finishBulk()
+	if len(remaining) == 0 {
+		return nil
+	}
+	ids := make([]int, len(remaining))
+	for i, t := range remaining {
+		ids[i] = t.ID
+	}
+	sort.Ints(ids)
+	return m.showStatus(statusWarning, fmt.Sprintf("Completed %d of %d tasks, skipped %d blocked: %s",
+		len(tasks)-len(remaining), len(tasks), len(remaining), task.FormatIDs(ids)))
+}
+
+// uncompleteTasks reopens the given completed or cancelled tasks as a single
+// undoable step.
+func (m *Model) uncompleteTasks(tasks []*task.Task) {
+	m.undoManager.Begin()
+	for _, t := range tasks {
+		if t.IsCancelled() {
+			// Cancelled tasks are reopened by a status change, so undo cancels them again
+			_ = m.changeStatus(t, task.StatusTodo)
+			continue
+		}
+		// Tasks that may not be reopened are skipped
+		_ = m.reopenTask(t)
+	}
+	m.finishBulk()
+}
+
+// deleteTasks deletes the given tasks as a single undoable step.
+func (m *Model) deleteTasks(tasks []*task.Task) {
+	m.undoManager.Begin()
+	for _, t := range tasks {
+		if deletedTask := m.taskManager.DeleteTask(t.ID); deletedTask != nil {
+			m.undoManager.PushUndo(&task.DeleteCommand{Task: deletedTask})
+		}
+	}
+	m.finishBulk()
+}
+
+// clearCompleted deletes every completed task as a single undoable step.
+func (m *Model) clearCompleted() {
+	m.undoManager.Begin()
+	for _, cleared := range m.taskManager.ClearCompleted() {
+		m.undoManager.PushUndo(&task.DeleteCommand{Task: cleared})
+	}
+	m.finishBulk()
+}
+
+// setTasksPriority sets the priority of the given tasks as a single undoable step.
+func (m *Model) setTasksPriority(tasks []*task.Task, priority task.Priority) {
+	current := m.selectedTask()
+	m.undoManager.Begin()
+	for _, t := range tasks {
+		oldPriority := t.Priority
+		if oldPriority == priority {
+			continue
+		}
+		if updatedTask := m.taskManager.SetTaskPriority(t.ID, priority); updatedTask != nil {
+			m.undoManager.PushUndo(&task.PriorityCommand{
+				TaskID: updatedTask.ID,
+				Old:    oldPriority,
+				New:    priority,
+			})
+		}
+	}
+	m.finishBulk()
+	if current != nil {
+		m.followTask(current.ID)
+	}
+}
+
+// setTasksTags applies a tag edit (see ParseTagEdit) to the given tasks as a
+// single undoable step. Nothing changes when the edit is invalid for any task.
+func (m *Model) setTasksTags(tasks []*task.Task, edit string) error {
+	m.undoManager.Begin()
+	for _, t := range tasks {
+		oldTags := t.Tags
+		newTags, err := ParseTagEdit(t.Tags, edit)
+		if err == nil {
+			_, err = m.taskManager.SetTaskTags(t.ID, newTags)
+		}
+		if err != nil {
+			m.undoManager.Rollback(m.taskManager)
+			return err
+		}
+		if task.FormatTags(oldTags) == task.FormatTags(t.Tags) {
+			continue
+		}
+		m.undoManager.PushUndo(&task.TagsCommand{
+			TaskID: t.ID,
+			Old:    oldTags,
+			New:    t.Tags,
+		})
+	}
+	m.finishBulk()
+	return nil
+}
+
+// setTasksProject moves the given tasks to a project as a single undoable
+// step. Nothing changes when the project name is invalid.
+func (m *Model) setTasksProject(tasks []*task.Task, project string) error {
+	m.undoManager.Begin()
+	for _, t := range tasks {
+		oldProject := t.Project
+		if _, err := m.taskManager.SetTaskProject(t.ID, project); err != nil {
+			m.undoManager.Rollback(m.taskManager)
+			return err
+		}
+		if oldProject == t.Project {
+			continue
+		}
+		m.undoManager.PushUndo(&task.ProjectCommand{
+			TaskID: t.ID,
+			Old:    oldProject,
+			New:    t.Project,
+		})
+	}
+	m.finishBulk()
+	return nil
+}
+
+// setTasksStatus moves the given tasks to a status as a single undoable step.
+// Nothing changes when a task may not move to the status.
+func (m *Model) setTasksStatus(tasks []*task.Task, status task.Status) error {
+	current := m.selectedTask()
+	m.undoManager.Begin()
+	for _, t := range tasks {
+		if err := m.moveToStatus(t, status); err != nil {
+			m.undoManager.Rollback(m.taskManager)
+			m.invalidateCache()
+			return fmt.Errorf("#%d: %w", t.ID, err)
+		}
+	}
+	m.finishBulk()
+	if current != nil {
+		m.followTask(current.ID)
+	}
+	return nil
+}
+
+// moveToStatus moves a task to a status, reopening it first if it is closed,
+// and records the changes for undo.
+func (m *Model) moveToStatus(t *task.Task, status task.Status) error {
+	if status == task.StatusDone {
+		if t.IsCancelled() {
+			if err := m.changeStatus(t, task.StatusTodo); err != nil {
+				return err
+			}
+		}
+		return m.completeTask(t)
+	}
+	if err := m.reopenTask(t); err != nil {
+		return err
+	}
+	return m.changeStatus(t, status)
+}
+
+// reopenTask reopens a completed task with its previous status, recording it
+// for undo. Open and cancelled tasks are left alone.
+func (m *Model) reopenTask(t *task.Task) error {
+	if t.CurrentStatus() != task.StatusDone {
+		return nil
+	}
+	if err := m.taskManager.CheckTransition(t, t.ReopenedStatus()); err != nil {
+		return err
+	}
+	completedAt := t.CompletedAt
+	if m.taskManager.UncompleteTask(t.ID) == nil {
+		return task.ErrTaskNotFound
+	}
+	m.undoManager.PushUndo(&task.UncompleteCommand{TaskID: t.ID, CompletedAt: completedAt})
+	return nil
+}
+
+// completeTask completes a task and records it for undo, or explains why the
+// task cannot be completed.
+func (m *Model) completeTask(t *task.Task) error {
+	if t.CurrentStatus() == task.StatusDone {
+		return nil
+	}
+	if t.IsDone {
+		return task.ErrTaskDone
+	}
+	if blockers := m.taskManager.Blockers(t.ID); len(blockers) > 0 {
+		return fmt.Errorf("blocked by %s", m.blockerIDs(t))
+	}
+	if err := m.taskManager.CheckTransition(t, task.StatusDone); err != nil {
+		return err
+	}
+	if completedTask := m.taskManager.CompleteTask(t.ID); completedTask != nil {
+		m.undoManager.PushUndo(&task.CompleteCommand{TaskID: completedTask.ID, CompletedAt: completedTask.CompletedAt})
+	}
+	return nil
+}
+
+// changeStatus sets the status of an open or cancelled task and records the
+// change for undo.
+func (m *Model) changeStatus(t *task.Task, status task.Status) error {
+	oldStatus, before := t.Status, t.CurrentStatus()
+	previousAt := t.StatusAt[status]
+	if _, err := m.taskManager.SetTaskStatus(t.ID, status); err != nil {
+		return err
+	}
+	if t.CurrentStatus() == before {
+		return nil
+	}
+	m.undoManager.PushUndo(&task.StatusCommand{
+		TaskID:     t.ID,
+		Old:        oldStatus,
+		New:        status,
+		At:         t.StatusAt[status],
+		PreviousAt: previousAt,
+	})
+	return nil
+}
+
+// finishBulk commits the transaction of a bulk operation as one undo step,
+// clears the selection and keeps the cursor within the list.
+func (m *Model) finishBulk() {
+	m.undoManager.Commit()
+	m.invalidateCache()
+	m.clearSelection()
+	m.clampCursor(len(m.listTasks()))
+}
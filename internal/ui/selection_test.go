@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func newSelectionModel(t *testing.T, names ...string) *Model {
+	t.Helper()
+	tm := task.NewTaskManager(nil, nil, 0)
+	for _, name := range names {
+		tm.AddTask(name)
+	}
+	m, err := NewTestModel(config.DefaultConfig(), tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.cursor = 1
+	return m
+}
+
+func TestSelection(t *testing.T) {
+	m := newSelectionModel(t, "one", "two", "three", "four")
+
+	m.toggleMark()
+	if m.cursor != 2 {
+		t.Errorf("expected marking to move the cursor down, got %d", m.cursor)
+	}
+	m.toggleVisual()
+	m.cursor = 3
+	if got := len(m.selectedTasks()); got != 3 {
+		t.Errorf("expected mark plus visual range of 3 tasks, got %d", got)
+	}
+	m.toggleVisual()
+	m.cursor = 4
+	if m.visual || len(m.selectedTasks()) != 3 {
+		t.Errorf("expected the range to be kept as marks, got %d selected", len(m.selectedTasks()))
+	}
+
+	m.toggleSelectAll()
+	if got := len(m.selectedTasks()); got != 4 {
+		t.Errorf("expected all tasks to be marked, got %d", got)
+	}
+	m.toggleSelectAll()
+	if m.hasSelection() {
+		t.Error("expected select all to unmark when everything is marked")
+	}
+}
+
+func TestBulkOperationsUndoInOneStep(t *testing.T) {
+	m := newSelectionModel(t, "one", "two", "three")
+	m.toggleSelectAll()
+	m.setTasksPriority(m.selectedTasks(), task.PriorityHigh)
+	for _, tk := range m.taskManager.GetTasks() {
+		if tk.Priority != task.PriorityHigh {
+			t.Errorf("expected %q to have high priority", tk.Name)
+		}
+	}
+	if m.hasSelection() {
+		t.Error("expected the selection to be cleared after a bulk operation")
+	}
+
+	m.toggleSelectAll()
+	m.completeTasks(m.selectedTasks())
+	if len(m.taskManager.GetTasks()) != 0 || len(m.taskManager.GetDoneTasks()) != 3 {
+		t.Fatal("expected all tasks to be completed")
+	}
+
+	m.undoManager.Undo(m.taskManager)
+	if len(m.taskManager.GetTasks()) != 3 {
+		t.Errorf("expected one undo to reopen every task, got %d active", len(m.taskManager.GetTasks()))
+	}
+	m.undoManager.Undo(m.taskManager)
+	for _, tk := range m.taskManager.GetTasks() {
+		if tk.Priority != task.PriorityNone {
+			t.Errorf("expected one undo to restore the priority of %q", tk.Name)
+		}
+	}
+}
+
+func TestCompleteTasksOrdersBlockers(t *testing.T) {
+	m := newSelectionModel(t, "blocker", "blocked")
+	blocker := m.taskManager.FindTaskByID(1)
+	blocked := m.taskManager.FindTaskByID(2)
+	if err := m.taskManager.SetDependencies(blocked.ID, []int{blocker.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The newest task, which is the blocked one, comes first in the list
+	m.completeTasks([]*task.Task{blocked, blocker})
+	if !blocked.IsDone || !blocker.IsDone {
+		t.Error("expected both tasks to be completed")
+	}
+}
+
+func TestCompleteTasksReportsBlocked(t *testing.T) {
+	m := newSelectionModel(t, "blocker", "blocked", "also blocked", "free")
+	for _, id := range []int{2, 3} {
+		if err := m.taskManager.SetDependencies(id, []int{1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m.completeTasks([]*task.Task{m.taskManager.FindTaskByID(4), m.taskManager.FindTaskByID(3), m.taskManager.FindTaskByID(2)})
+	if !m.taskManager.FindTaskByID(4).IsDone {
+		t.Error("expected the unblocked task to be completed")
+	}
+	if m.status.level != statusWarning || m.status.text != "Completed 1 of 3 tasks, skipped 2 blocked: #2, #3" {
+		t.Errorf("expected the skipped tasks to be reported, got %q", m.status.text)
+	}
+}
+
+func TestSetTasksTags(t *testing.T) {
+	m := newSelectionModel(t, "one", "two")
+	tasks := m.taskManager.GetTasks()
+	if err := m.setTasksTags(tasks, "+ops"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.setTasksTags(tasks, "bad!tag"); err == nil {
+		t.Error("expected an invalid edit to be rejected")
+	}
+	for _, tk := range tasks {
+		if !tk.HasTag("ops") {
+			t.Errorf("expected %q to be tagged", tk.Name)
+		}
+	}
+
+	m.undoManager.Undo(m.taskManager)
+	for _, tk := range tasks {
+		if len(tk.Tags) != 0 {
+			t.Errorf("expected undo to remove the tag from %q", tk.Name)
+		}
+	}
+}
+
+func TestSetTasksStatus(t *testing.T) {
+	m := newSelectionModel(t, "one", "two")
+	tasks := m.taskManager.GetTasks()
+	if err := m.setTasksStatus(tasks, task.StatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.taskManager.GetTasks()) != 0 || countCancelled(m.taskManager.GetDoneTasks()) != 2 {
+		t.Fatal("expected both tasks to be cancelled")
+	}
+
+	// Reopening cancelled tasks makes them todo, and undo cancels them again
+	m.uncompleteTasks(tasks)
+	for _, tk := range tasks {
+		if tk.CurrentStatus() != task.StatusTodo {
+			t.Errorf("expected %q to be reopened as todo, got %q", tk.Name, tk.CurrentStatus())
+		}
+	}
+	m.undoManager.Undo(m.taskManager)
+	if countCancelled(m.taskManager.GetDoneTasks()) != 2 {
+		t.Error("expected undo to cancel the tasks again")
+	}
+	m.undoManager.Undo(m.taskManager)
+	if len(m.taskManager.GetTasks()) != 2 {
+		t.Error("expected undo to reopen the tasks")
+	}
+
+	// Nothing changes when one of the tasks may not move
+	transitions, _ := task.ParseTransitions(map[string][]string{"waiting": {"in_progress"}})
+	m.taskManager.SetTransitions(transitions)
+	if err := m.setTasksStatus(tasks[:1], task.StatusWaiting); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.setTasksStatus(tasks, task.StatusDone); err == nil || !errors.Is(err, task.ErrTransitionNotAllowed) {
+		t.Errorf("expected waiting → done to be refused, got %v", err)
+	}
+	if len(m.taskManager.GetDoneTasks()) != 0 || tasks[0].CurrentStatus() != task.StatusWaiting {
+		t.Error("expected the refused change to be rolled back")
+	}
+}
+
+func TestTaskStatusPrompt(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	deploy := tm.AddTask("deploy")
+	tm.AddTask("docs")
+	m := NewModel(config.DefaultConfig(), tm)
+	m.followTask(deploy.ID)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.mode != ModeTaskStatus || m.taskStatusInput.Value() != "todo" {
+		t.Fatalf("expected the status prompt prefilled with todo, got %q", m.taskStatusInput.Value())
+	}
+	m.taskStatusInput.SetValue("later")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeTaskStatus || !strings.Contains(m.status.text, "unknown status") {
+		t.Fatalf("expected an unknown status to be rejected, got %q", m.status.text)
+	}
+	m.taskStatusInput.SetValue("waiting")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || deploy.CurrentStatus() != task.StatusWaiting || !strings.Contains(m.View(), "⧗ waiting") {
+		t.Fatalf("expected the task to be waiting, got %q", deploy.CurrentStatus())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m.taskStatusInput.SetValue("cancelled")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if view := m.View(); !strings.Contains(view, "(0 done, 1 cancelled)") || !strings.Contains(view, "✗ cancelled") {
+		t.Errorf("expected the cancelled task in the completed list, got:\n%s", view)
+	}
+}
+
+func TestDoneListShowsClosingTime(t *testing.T) {
+	m := newSelectionModel(t, "deploy", "docs")
+	tasks := m.taskManager.GetTasks()
+	if err := m.completeTask(tasks[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.setTasksStatus([]*task.Task{tasks[1]}, task.StatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+	m.mode = ModeDoneTaskList
+	m.invalidateCache()
+
+	view := m.View()
+	completed := "completed " + tasks[0].CompletedAt.Format("2006-01-02 15:04")
+	cancelled := "cancelled " + tasks[1].StatusSince().Format("2006-01-02 15:04")
+	for _, want := range []string{completed, cancelled} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the done list to show %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "docs") > strings.Index(view, "deploy") {
+		t.Error("expected the most recently closed task first")
+	}
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// sparkLevels are the blocks of a sparkline, lowest first.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// startStats opens the statistics screen.
+func (m *Model) startStats() {
+	m.promptListMode = m.listMode()
+	m.clearSelection()
+	m.mode = ModeStats
+}
+
+// statsUpdate handles updates on the statistics screen.
+func (m *Model) statsUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.Stats):
+			m.mode = m.promptListMode
+		}
+	}
+
+	return m, nil
+}
+
+// statsView renders the statistics of all tasks, open and closed.
+func (m *Model) statsView() string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("%v\n\n", termenv.String("STATISTICS").Bold().Underline()))
+
+	tasks := append(m.taskManager.GetTasks(), m.taskManager.GetDoneTasks()...)
+	stats := task.ComputeStats(tasks, time.Now(), StatsDays, StatsWeeks)
+	s.WriteString(RenderStats(stats, m.inputStyle))
+
+	s.WriteString("\n" + mutedStyle.Render("esc close") + "\n")
+	return s.String()
+}
+
+// RenderStats renders statistics as text, with sparklines of the recent days
+// and bar charts drawn in the accent style.
+func RenderStats(stats task.Stats, accent lipgloss.Style) string {
+	var s strings.Builder
+	header := lipgloss.NewStyle().Bold(true)
+
+	s.WriteString(fmt.Sprintf("%d open · %d completed · %d cancelled\n", stats.Open, stats.Completed, stats.Cancelled))
+	average := "-"
+	if stats.AverageCompletionHours > 0 {
+		average = task.FormatDuration(time.Duration(stats.AverageCompletionHours * float64(time.Hour)))
+	}
+	s.WriteString(fmt.Sprintf("Average time to complete: %s\n", average))
+	s.WriteString(fmt.Sprintf("Streak: %s (best %s)\n", pluralDays(stats.Streak), pluralDays(stats.BestStreak)))
+
+	if len(stats.Days) > 0 {
+		s.WriteString("\n" + header.Render(fmt.Sprintf("Last %d days, since %s", len(stats.Days), stats.Days[0].Start.Format(dayLayout))) + "\n")
+		series := []struct {
+			name  string
+			count func(task.Period) int
+		}{
+			{"created", func(p task.Period) int { return p.Created }},
+			{"completed", func(p task.Period) int { return p.Completed }},
+			{"cancelled", func(p task.Period) int { return p.Cancelled }},
+		}
+		for _, series := range series {
+			values := make([]int, len(stats.Days))
+			total := 0
+			for i, day := range stats.Days {
+				values[i] = series.count(day)
+				total += values[i]
+			}
+			s.WriteString(fmt.Sprintf("  %-10s %s %d\n", series.name, accent.Render(sparkline(values)), total))
+		}
+	}
+
+	if len(stats.Weeks) > 0 {
+		s.WriteString("\n" + header.Render("Created and completed per week") + "\n")
+		most := 0
+		for _, week := range stats.Weeks {
+			most = max(most, week.Created, week.Completed)
+		}
+		for _, week := range stats.Weeks {
+			writeRow(&s, fmt.Sprintf("  %s  +%-3d %s", week.Start.Format(dayLayout), week.Created, mutedStyle.Render(bar(week.Created, most))))
+			writeRow(&s, fmt.Sprintf("  %10s  ✓%-3d %s", "", week.Completed, accent.Render(bar(week.Completed, most))))
+		}
+	}
+
+	for _, counts := range []struct {
+		title  string
+		counts []task.Count
+	}{
+		{"Open by priority", stats.OpenByPriority},
+		{"Open by age", stats.OpenByAge},
+	} {
+		s.WriteString("\n" + header.Render(counts.title) + "\n")
+		most := 0
+		for _, count := range counts.counts {
+			most = max(most, count.Count)
+		}
+		for _, count := range counts.counts {
+			writeRow(&s, fmt.Sprintf("  %-14s %3d %s", count.Name, count.Count, accent.Render(bar(count.Count, most))))
+		}
+	}
+
+	return s.String()
+}
+
+// writeRow writes a row of a chart without the space left by an empty bar.
+func writeRow(s *strings.Builder, row string) {
+	s.WriteString(strings.TrimRight(row, " ") + "\n")
+}
+
+// sparkline draws values as a row of blocks scaled to the largest one. Only
+// zero gets the lowest block.
+func sparkline(values []int) string {
+	most := 0
+	for _, value := range values {
+		most = max(most, value)
+	}
+	line := make([]rune, len(values))
+	for i, value := range values {
+		level := 0
+		if value > 0 {
+			level = max(value*(len(sparkLevels)-1)/most, 1)
+		}
+		line[i] = sparkLevels[level]
+	}
+	return string(line)
+}
+
+// bar draws a value as a bar of up to StatsBarWidth cells, scaled to the
+// largest value. Values above zero get at least one cell.
+func bar(value, most int) string {
+	if value <= 0 || most <= 0 {
+		return ""
+	}
+	return strings.Repeat("█", max(value*StatsBarWidth/most, 1))
+}
+
+// pluralDays formats a number of days, e.g. "1 day" or "3 days".
+func pluralDays(days int) string {
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
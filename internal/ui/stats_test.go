@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStatsScreen(t *testing.T) {
+	m := newSelectionModel(t, "deploy", "docs")
+	if err := m.completeTask(m.taskManager.GetTasks()[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	if m.mode != ModeStats {
+		t.Fatalf("expected the statistics screen, got mode %d", m.mode)
+	}
+	view := m.View()
+	for _, want := range []string{"STATISTICS", "1 open · 1 completed · 0 cancelled", "Streak: 1 day", "Open by priority"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected the statistics to show %q, got:\n%s", want, view)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal {
+		t.Errorf("expected esc to close the statistics, got mode %d", m.mode)
+	}
+}
+
+func TestSparklineAndBar(t *testing.T) {
+	if got := sparkline([]int{0, 1, 7, 3}); got != "▁▂█▄" {
+		t.Errorf("expected ▁▂█▄, got %s", got)
+	}
+	if got := sparkline([]int{0, 0}); got != "▁▁" {
+		t.Errorf("expected an empty sparkline, got %s", got)
+	}
+	if got := bar(1, 100); got != "█" {
+		t.Errorf("expected a bar of at least one cell, got %q", got)
+	}
+	if got := bar(5, 5); len([]rune(got)) != StatsBarWidth {
+		t.Errorf("expected the longest bar to be %d cells, got %d", StatsBarWidth, len([]rune(got)))
+	}
+}
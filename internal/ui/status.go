@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusLevel is the severity of a status message.
+type statusLevel int
+
+const (
+	statusInfo statusLevel = iota
+	statusWarning
+	statusError
+)
+
+// statusDurations is how long a status message of each level is shown.
+var statusDurations = map[statusLevel]time.Duration{
+	statusInfo:    3 * time.Second,
+	statusWarning: 5 * time.Second,
+	statusError:   8 * time.Second,
+}
+
+// statusMessage is a message shown in the status line at the bottom.
+type statusMessage struct {
+	text  string
+	level statusLevel
+}
+
+// clearStatusMsg is sent when the status message with the given sequence
+// number expires. Newer messages are kept.
+type clearStatusMsg struct {
+	seq int
+}
+
+// saveFailedMsg is sent when the tasks could not be saved before quitting.
+type saveFailedMsg struct {
+	err error
+}
+
+// showStatus shows a message in the status line until it expires or is
+// replaced by another one.
+func (m *Model) showStatus(level statusLevel, text string) tea.Cmd {
+	m.status = statusMessage{text: text, level: level}
+	m.statusSeq++
+	seq := m.statusSeq
+	return tea.Tick(statusDurations[level], func(time.Time) tea.Msg {
+		return clearStatusMsg{seq: seq}
+	})
+}
+
+// clearStatus removes the status message.
+func (m *Model) clearStatus() {
+	m.status = statusMessage{}
+	m.statusSeq++
+}
+
+// statusLine renders the status message, or returns an empty string when
+// there is none.
+func (m *Model) statusLine() string {
+	if m.status.text == "" {
+		return ""
+	}
+	color := m.config.Theme.PrimaryColor
+	switch m.status.level {
+	case statusWarning:
+		color = m.config.Theme.MediumPriorityColor
+	case statusError:
+		color = m.config.Theme.HighPriorityColor
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	if m.width > 0 {
+		style = style.MaxWidth(m.width)
+	}
+	return style.Render(m.status.text)
+}
+
+// undo reverts the last change and reports the outcome in the status line.
+func (m *Model) undo() tea.Cmd {
+	undo, _ := m.undoManager.Entries()
+	if len(undo) == 0 {
+		return m.showStatus(statusWarning, "Nothing to undo")
+	}
+	description := undo[len(undo)-1].Command.Describe(m.taskManager)
+	if err := m.undoManager.Undo(m.taskManager); err != nil {
+		// The change is dropped, undo continues with the one before it
+		m.invalidateCache()
+		return m.showStatus(statusError, fmt.Sprintf("Could not undo %s: %v", description, err))
+	}
+	m.invalidateCache()
+	return tea.Batch(m.tickTimer(), m.showStatus(statusInfo, "Undid "+description))
+}
+
+// redo re-applies the last undone change and reports the outcome in the
+// status line.
+func (m *Model) redo() tea.Cmd {
+	_, redo := m.undoManager.Entries()
+	if len(redo) == 0 {
+		return m.showStatus(statusWarning, "Nothing to redo")
+	}
+	description := redo[0].Command.Describe(m.taskManager)
+	if err := m.undoManager.Redo(m.taskManager); err != nil {
+		return m.showStatus(statusError, fmt.Sprintf("Could not redo %s: %v", description, err))
+	}
+	m.invalidateCache()
+	return tea.Batch(m.tickTimer(), m.showStatus(statusInfo, "Redid "+description))
+}
+
+// saveFailed keeps the application open after the tasks could not be saved
+// and offers to retry or to quit anyway.
+func (m *Model) saveFailed(err error) {
+	m.promptListMode = m.listMode()
+	m.mode = ModeSaveFailed
+	m.status = statusMessage{
+		text: fmt.Sprintf("Could not save tasks: %v · %s · %s · %s keep working", err,
+			helpText(m.keys.Retry), helpText(m.keys.QuitWithoutSaving), m.keys.Escape.Help().Key),
+		level: statusError,
+	}
+	m.statusSeq++
+}
+
+// saveFailedUpdate handles updates after saving failed.
+func (m *Model) saveFailedUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Retry):
+			m.mode = m.promptListMode
+			m.clearStatus()
+			return m, m.saveAndQuitCmd()
+		case key.Matches(msg, m.keys.QuitWithoutSaving):
+			m.quitting = true
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Escape):
+			m.mode = m.promptListMode
+			m.clearStatus()
+		}
+	}
+	return m, nil
+}
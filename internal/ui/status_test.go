@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestStatusLine(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	m := NewModel(config.DefaultConfig(), tm)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if !strings.Contains(m.View(), "Nothing to undo") {
+		t.Errorf("expected a warning when there is nothing to undo, got:\n%s", m.View())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeAdditional || !strings.Contains(m.View(), "task name cannot be empty") {
+		t.Fatalf("expected an invalid name to be reported, got:\n%s", m.View())
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	deploy := tm.AddTask("deploy")
+	m.undoManager.PushUndo(&task.AddCommand{Task: deploy})
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if !strings.Contains(m.View(), `Undid added #1 "deploy"`) {
+		t.Errorf("expected the undone change in the status line, got:\n%s", m.View())
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !strings.Contains(m.View(), `Redid added #1 "deploy"`) {
+		t.Errorf("expected the redone change in the status line, got:\n%s", m.View())
+	}
+
+	// Only the latest message expires
+	m.Update(clearStatusMsg{seq: m.statusSeq - 1})
+	if m.statusLine() == "" {
+		t.Error("expected an outdated expiry to keep the message")
+	}
+	m.Update(clearStatusMsg{seq: m.statusSeq})
+	if m.statusLine() != "" {
+		t.Errorf("expected the message to expire, got %q", m.statusLine())
+	}
+}
+
+func TestSaveFailed(t *testing.T) {
+	notADir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notADir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.DataFile = filepath.Join(notADir, "tasks.json")
+	tm := task.NewTaskManager(nil, nil, 0)
+	tm.AddTask("deploy")
+	m := NewModel(cfg, tm)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m.Update(cmd())
+	if m.quitting || m.mode != ModeSaveFailed || !strings.Contains(m.View(), "Could not save tasks") {
+		t.Fatalf("expected a failed save to keep the app open, got:\n%s", m.View())
+	}
+
+	// Retrying fails again, escape returns to the list
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m.Update(cmd())
+	if m.mode != ModeSaveFailed {
+		t.Fatalf("expected the retry to fail, got mode %d", m.mode)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal || m.statusLine() != "" {
+		t.Fatalf("expected escape to return to the list, got mode %d", m.mode)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m.Update(cmd())
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Q")})
+	if !m.quitting {
+		t.Error("expected Q to quit without saving")
+	}
+}
+
+func TestSaveFailedRemappedKeys(t *testing.T) {
+	notADir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notADir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.DataFile = filepath.Join(notADir, "tasks.json")
+	m := NewModel(cfg, task.NewTaskManager(nil, nil, 0))
+	m.keys.Retry = key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "retry"))
+	m.keys.QuitWithoutSaving = key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "quit without saving"))
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m.Update(cmd())
+	if !strings.Contains(m.statusLine(), "R retry · X quit without saving") {
+		t.Errorf("expected the status to name the remapped keys, got %q", m.statusLine())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Q")})
+	if m.quitting {
+		t.Fatal("expected Q to do nothing once remapped")
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if cmd == nil {
+		t.Fatal("expected R to retry saving")
+	}
+	m.Update(cmd())
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if !m.quitting {
+		t.Error("expected X to quit without saving")
+	}
+}
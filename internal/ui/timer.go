@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// timerTickMsg redraws the running timer. Only the tick with the latest
+// sequence number schedules the next one.
+type timerTickMsg struct {
+	seq int
+}
+
+// tickTimer keeps the running timer in the header up to date by redrawing it
+// every second while a timer runs. Each call starts a new chain of ticks.
+func (m *Model) tickTimer() tea.Cmd {
+	if m.taskManager.RunningTimer() == nil {
+		return nil
+	}
+	m.timerSeq++
+	seq := m.timerSeq
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return timerTickMsg{seq: seq} })
+}
+
+// toggleTimer stops the timer of the selected task if it runs, and starts it
+// otherwise, stopping the timer running on another task. Either is one
+// undoable step.
+func (m *Model) toggleTimer() tea.Cmd {
+	t := m.selectedTask()
+	if t == nil {
+		return nil
+	}
+	now := time.Now()
+
+	var commands []task.Command
+	if running := m.taskManager.RunningTimer(); running != nil {
+		entry, err := m.taskManager.StopTimer(running.ID, now)
+		if err != nil {
+			return m.showStatus(statusError, fmt.Sprintf("Could not stop the timer of #%d: %v", running.ID, err))
+		}
+		commands = append(commands, &task.TimerCommand{TaskID: running.ID, Start: entry.Start, End: entry.End})
+		if running == t {
+			m.undoManager.PushUndo(task.NewBatch(commands...))
+			return m.showStatus(statusInfo, fmt.Sprintf("Stopped the timer of #%d after %s, %s in total",
+				t.ID, task.FormatDuration(entry.Duration(now)), task.FormatDuration(t.TrackedTime(now))))
+		}
+	}
+
+	if _, err := m.taskManager.StartTimer(t.ID, now); err != nil {
+		if len(commands) > 0 {
+			m.undoManager.PushUndo(task.NewBatch(commands...))
+		}
+		return m.showStatus(statusWarning, fmt.Sprintf("Could not start the timer of #%d: %v", t.ID, err))
+	}
+	commands = append(commands, &task.TimerCommand{TaskID: t.ID, Start: now})
+	m.undoManager.PushUndo(task.NewBatch(commands...))
+	return tea.Batch(m.tickTimer(), m.showStatus(statusInfo, fmt.Sprintf("Started the timer of #%d", t.ID)))
+}
+
+// timerHeader describes the running timer for the list title, or returns an
+// empty string when no timer runs.
+func (m *Model) timerHeader() string {
+	running := m.taskManager.RunningTimer()
+	if running == nil {
+		return ""
+	}
+	elapsed := running.RunningEntry().Duration(time.Now())
+	return m.inputStyle.Render(fmt.Sprintf("  ⏱ #%d %s %s", running.ID, running.Name, formatClock(elapsed)))
+}
+
+// formatClock formats a duration as hours, minutes and seconds, e.g. 1:05:09.
+func formatClock(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
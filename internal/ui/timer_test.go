@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestToggleTimer(t *testing.T) {
+	m := newSelectionModel(t, "deploy", "docs")
+	tasks := m.listTasks()
+	press := func() tea.Cmd {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+		return cmd
+	}
+
+	if cmd := press(); cmd == nil || m.taskManager.RunningTimer() != tasks[0] {
+		t.Fatal("expected the timer of the selected task to start ticking")
+	}
+	if view := m.View(); !strings.Contains(view, fmt.Sprintf("⏱ #%d %s 0:00:0", tasks[0].ID, tasks[0].Name)) {
+		t.Errorf("expected the running timer in the header, got:\n%s", view)
+	}
+
+	// Starting another timer stops the running one in the same step
+	m.cursor = 2
+	press()
+	if m.taskManager.RunningTimer() != tasks[1] || tasks[0].RunningEntry() != nil || len(tasks[0].TimeEntries) != 1 {
+		t.Fatal("expected the timer to move to the other task")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if m.taskManager.RunningTimer() != tasks[0] || len(tasks[1].TimeEntries) != 0 {
+		t.Fatal("expected one undo to move the timer back")
+	}
+
+	m.cursor = 1
+	press()
+	if m.taskManager.RunningTimer() != nil || !strings.Contains(m.status.text, "Stopped the timer of #") {
+		t.Errorf("expected the timer to stop, got %q", m.status.text)
+	}
+	if detail := m.detailView(tasks[0], 80); !strings.Contains(detail, "Tracked") || strings.Contains(detail, "running") {
+		t.Errorf("expected the tracked time in the details, got:\n%s", detail)
+	}
+
+	// Ticks only go on while a timer runs
+	if _, cmd := m.Update(timerTickMsg{seq: m.timerSeq}); cmd != nil {
+		t.Error("expected no tick without a running timer")
+	}
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// undoHistoryChromeLines is the number of lines of the undo history view that
+// are not entries: the title and its blank line, and the blank line and hint
+// below the entries.
+const undoHistoryChromeLines = 4
+
+// startUndoHistory opens the undo history with the cursor on the current state.
+func (m *Model) startUndoHistory() {
+	m.promptListMode = m.listMode()
+	m.historyCursor = m.undoManager.Position()
+	m.mode = ModeUndoHistory
+}
+
+// closeUndoHistory returns to the list the undo history was opened from.
+func (m *Model) closeUndoHistory() {
+	m.mode = m.promptListMode
+	m.invalidateCache()
+	m.clearSelection()
+	m.clampCursor(len(m.listTasks()))
+}
+
+// undoHistoryEntries returns the undone and the redoable commands as one
+// history, oldest first. Position n of the history is the state after its
+// first n commands.
+func (m *Model) undoHistoryEntries() []task.UndoEntry {
+	undo, redo := m.undoManager.Entries()
+	return append(undo, redo...)
+}
+
+// undoHistoryUpdate handles updates while browsing the undo history. The
+// newest state is shown at the top, so moving up moves forward in time.
+func (m *Model) undoHistoryUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		total := len(m.undoHistoryEntries())
+		switch {
+		case key.Matches(msg, m.keys.Escape), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.UndoHistory):
+			m.closeUndoHistory()
+		case key.Matches(msg, m.keys.Up):
+			if m.historyCursor < total {
+				m.historyCursor++
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.historyCursor > 0 {
+				m.historyCursor--
+			}
+		case key.Matches(msg, m.keys.Home):
+			m.historyCursor = total
+		case key.Matches(msg, m.keys.End):
+			m.historyCursor = 0
+		case key.Matches(msg, m.keys.Enter):
+			// Stops early when a step cannot be undone or redone
+			err := m.undoManager.JumpTo(m.taskManager, m.historyCursor)
+			m.historyCursor = m.undoManager.Position()
+			m.invalidateCache()
+			if err != nil {
+				return m, tea.Batch(m.tickTimer(), m.showStatus(statusError, "Could not jump there: "+err.Error()))
+			}
+			return m, m.tickTimer()
+		case key.Matches(msg, m.keys.Undo):
+			cmd := m.undo()
+			m.historyCursor = m.undoManager.Position()
+			return m, cmd
+		case key.Matches(msg, m.keys.Redo):
+			cmd := m.redo()
+			m.historyCursor = m.undoManager.Position()
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// undoHistoryView renders the undo history, newest first. Entries after the
+// current state have been undone and can be redone.
+func (m *Model) undoHistoryView() string {
+	var s strings.Builder
+	title := termenv.String("UNDO HISTORY").Bold().Underline()
+	s.WriteString(fmt.Sprintf("%v\n\n", title))
+
+	entries := m.undoHistoryEntries()
+	current := m.undoManager.Position()
+
+	// Rows from the newest state down to the oldest one
+	total := len(entries) + 1
+	start, end := 0, total
+	if m.height > 0 {
+		rows := m.height - undoHistoryChromeLines
+		if rows < 1 {
+			rows = 1
+		}
+		if total > rows {
+			start = len(entries) - m.historyCursor - rows/2
+			if start < 0 {
+				start = 0
+			}
+			if start > total-rows {
+				start = total - rows
+			}
+			end = start + rows
+		}
+	}
+
+	for row := start; row < end; row++ {
+		position := len(entries) - row
+		cursor := termenv.String(" ")
+		if position == m.historyCursor {
+			cursor = termenv.String(">").Foreground(termenv.ANSIYellow)
+		}
+		marker := " "
+		if position == current {
+			marker = m.inputStyle.Render("●")
+		}
+
+		var text string
+		if position == 0 {
+			text = mutedStyle.Render("start of history")
+		} else {
+			entry := entries[position-1]
+			text = fmt.Sprintf("%s  %s", entry.At.Format("Jan 02 15:04"), entry.Command.Describe(m.taskManager))
+			if position > current {
+				text = mutedStyle.Render(text)
+			}
+		}
+
+		line := fmt.Sprintf("%v%s %s", cursor, marker, text)
+		if m.width > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+		}
+		s.WriteString(line + "\n")
+	}
+
+	hint := fmt.Sprintf("%d undoable, %d redoable · enter jump here · %s/%s step · esc close",
+		current, len(entries)-current, m.config.KeyMap.Undo, m.config.KeyMap.Redo)
+	s.WriteString("\n" + mutedStyle.Render(hint) + "\n")
+
+	return s.String()
+}
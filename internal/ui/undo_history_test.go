@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/task"
+)
+
+func TestUndoHistoryJump(t *testing.T) {
+	m := newSelectionModel(t, "deploy")
+	deploy := m.taskManager.GetTasks()[0]
+	m.setTasksPriority([]*task.Task{deploy}, task.PriorityLow)
+	m.setTasksPriority([]*task.Task{deploy}, task.PriorityHigh)
+	m.completeTasks([]*task.Task{deploy})
+
+	m.startUndoHistory()
+	if m.mode != ModeUndoHistory || m.historyCursor != 3 {
+		t.Fatalf("expected history to open on the current state, got cursor %d", m.historyCursor)
+	}
+
+	view := m.undoHistoryView()
+	for _, want := range []string{`completed #1 "deploy"`, `priority #1 "deploy" low → high`, "start of history"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected history to show %q, got:\n%s", want, view)
+		}
+	}
+
+	// Jump back to right after the first priority change
+	m.undoHistoryUpdate(tea.KeyMsg{Type: tea.KeyDown})
+	m.undoHistoryUpdate(tea.KeyMsg{Type: tea.KeyDown})
+	m.undoHistoryUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+	if deploy.IsDone || deploy.Priority != task.PriorityLow {
+		t.Errorf("expected two steps to be undone, got done=%v priority=%s", deploy.IsDone, deploy.Priority)
+	}
+	if m.undoManager.Position() != 1 {
+		t.Errorf("expected position 1, got %d", m.undoManager.Position())
+	}
+
+	// And forward again to the newest state
+	m.undoHistoryUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m.undoHistoryUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+	if !deploy.IsDone || deploy.Priority != task.PriorityHigh {
+		t.Error("expected every step to be redone")
+	}
+
+	m.undoHistoryUpdate(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeNormal {
+		t.Errorf("expected esc to return to the task list, got mode %d", m.mode)
+	}
+}
+
+func TestPriorityKeyUndo(t *testing.T) {
+	m := newSelectionModel(t, "deploy")
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	deploy := m.taskManager.GetTasks()[0]
+	if deploy.Priority != task.PriorityHigh {
+		t.Fatalf("expected high priority, got %s", deploy.Priority)
+	}
+
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if deploy.Priority != task.PriorityNone {
+		t.Errorf("expected undo to restore the previous priority, got %s", deploy.Priority)
+	}
+}
@@ -1,6 +1,10 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -21,39 +25,34 @@ func (m *Model) normalUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor > 1 {
 				m.cursor--
 			}
-		case key.Matches(msg, m.keys.Right):
-			if m.cursor == 0 || m.cursor > len(m.taskCache) {
-				break
-			}
-			taskToEdit := m.taskCache[m.cursor-1]
-			m.mode = ModeEdit
-			m.editTaskNameInput.Placeholder = taskToEdit.Name
-			m.editTaskNameInput.SetValue("")
-			return m, m.editTaskNameInput.Focus()
-		case key.Matches(msg, m.keys.Edit):
+		case key.Matches(msg, m.keys.Right), key.Matches(msg, m.keys.Edit):
 			if m.cursor == 0 || m.cursor > len(m.taskCache) {
 				break
 			}
-			taskToEdit := m.taskCache[m.cursor-1]
-			m.mode = ModeEdit
-			m.editTaskNameInput.Placeholder = taskToEdit.Name
-			m.editTaskNameInput.SetValue("")
-			return m, m.editTaskNameInput.Focus()
+			return m, m.startEdit(m.taskCache[m.cursor-1])
 		case key.Matches(msg, m.keys.Enter):
+			if m.hasSelection() {
+				selected := m.selectedTasks()
+				return m, m.confirm(confirmBulk, fmt.Sprintf("Complete %s?", describeTasks(selected)), func() tea.Cmd {
+					return m.completeTasks(selected)
+				})
+			}
 			if m.cursor == 0 || m.cursor > len(m.taskCache) {
 				break
 			}
 			taskToComplete := m.taskCache[m.cursor-1]
 
-			oldState := taskToComplete.IsDone
 			if completedTask := m.taskManager.CompleteTask(taskToComplete.ID); completedTask != nil {
-				m.undoManager.PushUndo(task.Action{
-					Type:     task.ActionTypeComplete,
-					Task:     completedTask,
-					OldState: oldState,
-					NewState: true,
-				})
+				m.undoManager.PushUndo(&task.CompleteCommand{TaskID: completedTask.ID, CompletedAt: completedTask.CompletedAt})
 				m.invalidateCache()
+			} else if blockers := m.taskManager.Blockers(taskToComplete.ID); len(blockers) > 0 {
+				ids := make([]int, len(blockers))
+				for i, blocker := range blockers {
+					ids[i] = blocker.ID
+				}
+				return m, m.showStatus(statusWarning, fmt.Sprintf("#%d is blocked by %s", taskToComplete.ID, task.FormatIDs(ids)))
+			} else if err := m.taskManager.CheckTransition(taskToComplete, task.StatusDone); err != nil {
+				return m, m.showStatus(statusWarning, fmt.Sprintf("Could not complete #%d: %v", taskToComplete.ID, err))
 			}
 
 			if len(m.taskCache) == 0 {
@@ -64,29 +63,60 @@ func (m *Model) normalUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Add):
 			m.mode = ModeAdditional
 			return m, m.newTaskNameInput.Focus()
-		case key.Matches(msg, m.keys.Delete):
-			if m.cursor == 0 {
+		case key.Matches(msg, m.keys.BlockedBy):
+			if m.cursor == 0 || m.cursor > len(m.taskCache) {
 				break
 			}
-
-			taskToDelete := m.taskCache[m.cursor-1]
-			oldState := taskToDelete.IsDone
-			if deletedTask := m.taskManager.DeleteTask(taskToDelete.ID); deletedTask != nil {
-				m.undoManager.PushUndo(task.Action{
-					Type:     task.ActionTypeDelete,
-					Task:     deletedTask,
-					OldState: oldState,
-				})
-				m.invalidateCache()
-				m.updateTaskCache()
+			taskToLink := m.taskCache[m.cursor-1]
+			m.mode = ModeBlockedBy
+			m.blockedByInput.SetValue(task.FormatIDs(taskToLink.BlockedBy))
+			m.blockedByInput.CursorEnd()
+			return m, m.blockedByInput.Focus()
+		case key.Matches(msg, m.keys.EditNotes):
+			if t := m.getCurrentTask(); t != nil {
+				return m, m.editNotesCmd(t)
+			}
+		case key.Matches(msg, m.keys.ToggleDetail):
+			m.showDetail = !m.showDetail
+		case key.Matches(msg, m.keys.Search):
+			return m, m.startSearch()
+		case key.Matches(msg, m.keys.NextMatch):
+			m.jumpToMatch(false)
+		case key.Matches(msg, m.keys.PrevMatch):
+			m.jumpToMatch(true)
+		case key.Matches(msg, m.keys.ToggleMark):
+			m.toggleMark()
+		case key.Matches(msg, m.keys.VisualMode):
+			m.toggleVisual()
+		case key.Matches(msg, m.keys.SelectAll):
+			m.toggleSelectAll()
+		case key.Matches(msg, m.keys.EditTags):
+			return m, m.startTagEdit()
+		case key.Matches(msg, m.keys.EditProject):
+			return m, m.startProjectEdit()
+		case key.Matches(msg, m.keys.SetStatus):
+			return m, m.startStatusEdit()
+		case key.Matches(msg, m.keys.Escape):
+			if m.hasSelection() {
+				m.clearSelection()
+				break
 			}
-
-			if len(m.taskCache) == 0 {
-				m.cursor = 0
-			} else if m.cursor > len(m.taskCache) {
-				m.cursor = len(m.taskCache)
+			m.searchQuery = ""
+		case key.Matches(msg, m.keys.Recurrence):
+			if m.cursor == 0 || m.cursor > len(m.taskCache) {
+				break
+			}
+			m.mode = ModeRecurrence
+			m.recurrenceInput.SetValue("")
+			if t := m.taskCache[m.cursor-1]; t.Recurrence != nil {
+				m.recurrenceInput.SetValue(t.Recurrence.Rule())
+				m.recurrenceInput.CursorEnd()
 			}
+			return m, m.recurrenceInput.Focus()
+		case key.Matches(msg, m.keys.Delete):
+			return m, m.confirmDelete()
 		case key.Matches(msg, m.keys.ListType):
+			m.clearSelection()
 			if m.mode == ModeDoneTaskList {
 				if len(m.taskManager.GetTasks()) == 0 {
 					m.cursor = 0
@@ -105,76 +135,58 @@ func (m *Model) normalUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Quit):
 			return m, m.saveAndQuitCmd()
 		case key.Matches(msg, m.keys.Filter):
-			m.filter = (m.filter + 1) % 5
-			m.invalidateCache()
-			m.updateTaskCache()
+			m.cycleFilter()
 			return m, nil
+		case key.Matches(msg, m.keys.FilterQuery):
+			return m, m.startFilter()
 		case key.Matches(msg, m.keys.Help):
 			m.mode = ModeHelp
 		case key.Matches(msg, m.keys.Undo):
-			if m.undoManager.Undo(m.taskManager) {
-				m.invalidateCache()
-			}
+			return m, m.undo()
 		case key.Matches(msg, m.keys.Redo):
-			if m.undoManager.Redo(m.taskManager) {
-				m.invalidateCache()
-			}
+			return m, m.redo()
+		case key.Matches(msg, m.keys.UndoHistory):
+			m.startUndoHistory()
+		case key.Matches(msg, m.keys.Board):
+			m.startBoard()
+		case key.Matches(msg, m.keys.Agenda):
+			m.startAgenda()
+		case key.Matches(msg, m.keys.Calendar):
+			m.startCalendar()
+		case key.Matches(msg, m.keys.Stats):
+			m.startStats()
+		case key.Matches(msg, m.keys.Timer):
+			return m, m.toggleTimer()
+		case key.Matches(msg, m.keys.MoveUp):
+			m.moveTask(-1)
+		case key.Matches(msg, m.keys.MoveDown):
+			m.moveTask(1)
+		case key.Matches(msg, m.keys.Sort):
+			m.cycleSortMode()
+		case key.Matches(msg, m.keys.ReverseSort):
+			m.reverseSort()
+		case key.Matches(msg, m.keys.Views):
+			m.startViewPicker()
+		case key.Matches(msg, m.keys.ViewShortcut):
+			m.applyView(viewShortcutIndex(msg))
+		case key.Matches(msg, m.keys.Group):
+			m.cycleGroupMode()
 		case key.Matches(msg, m.keys.PriorityNone):
-			if len(m.taskCache) > 0 && m.cursor > 0 && m.cursor <= len(m.taskCache) {
-				taskToUpdate := m.taskCache[m.cursor-1]
-				if updatedTask := m.taskManager.SetTaskPriority(taskToUpdate.ID, task.PriorityNone); updatedTask != nil {
-					m.undoManager.PushUndo(task.Action{
-						Type:     task.ActionTypePriority,
-						Task:     updatedTask,
-						OldState: taskToUpdate.Priority,
-						NewState: task.PriorityNone,
-					})
-					m.invalidateCache()
-					m.followTask(taskToUpdate.ID)
-				}
-			}
+			return m, m.confirmPriority(task.PriorityNone)
 		case key.Matches(msg, m.keys.PriorityLow):
-			if len(m.taskCache) > 0 && m.cursor > 0 && m.cursor <= len(m.taskCache) {
-				taskToUpdate := m.taskCache[m.cursor-1]
-				if updatedTask := m.taskManager.SetTaskPriority(taskToUpdate.ID, task.PriorityLow); updatedTask != nil {
-					m.undoManager.PushUndo(task.Action{
-						Type:     task.ActionTypePriority,
-						Task:     updatedTask,
-						OldState: taskToUpdate.Priority,
-						NewState: task.PriorityLow,
-					})
-					m.invalidateCache()
-					m.followTask(taskToUpdate.ID)
-				}
-			}
+			return m, m.confirmPriority(task.PriorityLow)
 		case key.Matches(msg, m.keys.PriorityMedium):
-			if len(m.taskCache) > 0 && m.cursor > 0 && m.cursor <= len(m.taskCache) {
-				taskToUpdate := m.taskCache[m.cursor-1]
-				if updatedTask := m.taskManager.SetTaskPriority(taskToUpdate.ID, task.PriorityMedium); updatedTask != nil {
-					m.undoManager.PushUndo(task.Action{
-						Type:     task.ActionTypePriority,
-						Task:     updatedTask,
-						OldState: taskToUpdate.Priority,
-						NewState: task.PriorityMedium,
-					})
-					m.invalidateCache()
-					m.followTask(taskToUpdate.ID)
-				}
-			}
+			return m, m.confirmPriority(task.PriorityMedium)
 		case key.Matches(msg, m.keys.PriorityHigh):
-			if len(m.taskCache) > 0 && m.cursor > 0 && m.cursor <= len(m.taskCache) {
-				taskToUpdate := m.taskCache[m.cursor-1]
-				if updatedTask := m.taskManager.SetTaskPriority(taskToUpdate.ID, task.PriorityHigh); updatedTask != nil {
-					m.undoManager.PushUndo(task.Action{
-						Type:     task.ActionTypePriority,
-						Task:     updatedTask,
-						OldState: taskToUpdate.Priority,
-						NewState: task.PriorityHigh,
-					})
-					m.invalidateCache()
-					m.followTask(taskToUpdate.ID)
-				}
-			}
+			return m, m.confirmPriority(task.PriorityHigh)
+		case key.Matches(msg, m.keys.PageUp):
+			m.moveCursor(-m.pageSize(), len(m.taskCache))
+		case key.Matches(msg, m.keys.PageDown):
+			m.moveCursor(m.pageSize(), len(m.taskCache))
+		case key.Matches(msg, m.keys.HalfPageUp):
+			m.moveCursor(-max(m.pageSize()/2, 1), len(m.taskCache))
+		case key.Matches(msg, m.keys.HalfPageDown):
+			m.moveCursor(max(m.pageSize()/2, 1), len(m.taskCache))
 		case key.Matches(msg, m.keys.Home):
 			if len(m.taskCache) > 0 {
 				m.cursor = 1
@@ -188,15 +200,11 @@ func (m *Model) normalUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor = 0
 			}
 		case key.Matches(msg, m.keys.ClearCompleted):
-			doneTasks := m.taskManager.GetDoneTasks()
-			if len(doneTasks) > 0 {
-				// Create undo action for bulk delete
-				m.undoManager.PushUndo(task.Action{
-					Type: task.ActionTypeDelete,
-					Task: &task.Task{IsDone: true}, // Marker for bulk operation
+			if done := len(m.taskManager.GetDoneTasks()); done > 0 {
+				return m, m.confirm(confirmClearCompleted, fmt.Sprintf("Delete all %d completed tasks?", done), func() tea.Cmd {
+					m.clearCompleted()
+					return nil
 				})
-				// Clear all completed tasks
-				m.taskManager = task.NewTaskManager(m.taskManager.GetTasks(), []*task.Task{}, m.taskManager.GetNextID())
 			}
 		}
 	}
@@ -218,32 +226,36 @@ func (m *Model) doneTaskListUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor > 1 {
 				m.cursor--
 			}
+		case key.Matches(msg, m.keys.PageUp):
+			m.moveCursor(-m.pageSize(), len(doneTasks))
+		case key.Matches(msg, m.keys.PageDown):
+			m.moveCursor(m.pageSize(), len(doneTasks))
+		case key.Matches(msg, m.keys.HalfPageUp):
+			m.moveCursor(-max(m.pageSize()/2, 1), len(doneTasks))
+		case key.Matches(msg, m.keys.HalfPageDown):
+			m.moveCursor(max(m.pageSize()/2, 1), len(doneTasks))
+		case key.Matches(msg, m.keys.Home):
+			m.moveCursor(-len(doneTasks), len(doneTasks))
+		case key.Matches(msg, m.keys.End):
+			m.moveCursor(len(doneTasks), len(doneTasks))
 		case key.Matches(msg, m.keys.Delete):
-			if m.cursor == 0 {
-				break
-			}
-			m.taskManager.DeleteTask(doneTasks[m.cursor-1].ID)
-			if len(doneTasks) == 0 {
-				m.cursor = 0
-			} else {
-				m.cursor = 1
-			}
+			return m, m.confirmDelete()
 		case key.Matches(msg, m.keys.Enter):
+			if m.hasSelection() {
+				selected := m.selectedTasks()
+				return m, m.confirm(confirmBulk, fmt.Sprintf("Reopen %s?", describeTasks(selected)), func() tea.Cmd {
+					m.uncompleteTasks(selected)
+					return nil
+				})
+			}
 			if m.cursor == 0 {
 				break
 			}
 			t := doneTasks[m.cursor-1]
-
-			oldState := t.IsDone
-			if uncompletedTask := m.taskManager.UncompleteTask(t.ID); uncompletedTask != nil {
-				m.undoManager.PushUndo(task.Action{
-					Type:     task.ActionTypeUncomplete,
-					Task:     uncompletedTask,
-					OldState: oldState,
-					NewState: false,
-				})
-				m.invalidateCache()
+			if err := m.taskManager.CheckTransition(t, t.ReopenedStatus()); err != nil {
+				return m, m.showStatus(statusWarning, fmt.Sprintf("Could not reopen #%d: %v", t.ID, err))
 			}
+			m.uncompleteTasks([]*task.Task{t})
 
 			if len(doneTasks) == 0 {
 				m.cursor = 0
@@ -251,6 +263,7 @@ func (m *Model) doneTaskListUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor = 1
 			}
 		case key.Matches(msg, m.keys.ListType):
+			m.clearSelection()
 			if len(m.taskManager.GetTasks()) == 0 {
 				m.cursor = 0
 			} else {
@@ -261,14 +274,54 @@ func (m *Model) doneTaskListUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Quit):
 			return m, m.saveAndQuitCmd()
 		case key.Matches(msg, m.keys.Undo):
-			if m.undoManager.Undo(m.taskManager) {
-				m.invalidateCache()
-			}
+			return m, m.undo()
 		case key.Matches(msg, m.keys.Redo):
-			if m.undoManager.Redo(m.taskManager) {
-				m.invalidateCache()
-			}
+			return m, m.redo()
+		case key.Matches(msg, m.keys.UndoHistory):
+			m.startUndoHistory()
+		case key.Matches(msg, m.keys.Board):
+			m.startBoard()
+		case key.Matches(msg, m.keys.Agenda):
+			m.startAgenda()
+		case key.Matches(msg, m.keys.Calendar):
+			m.startCalendar()
+		case key.Matches(msg, m.keys.Stats):
+			m.startStats()
+		case key.Matches(msg, m.keys.Timer):
+			return m, m.toggleTimer()
+		case key.Matches(msg, m.keys.EditNotes):
+			if t := m.selectedTask(); t != nil {
+				return m, m.editNotesCmd(t)
+			}
+		case key.Matches(msg, m.keys.ToggleDetail):
+			m.showDetail = !m.showDetail
+		case key.Matches(msg, m.keys.Search):
+			return m, m.startSearch()
+		case key.Matches(msg, m.keys.NextMatch):
+			m.jumpToMatch(false)
+		case key.Matches(msg, m.keys.PrevMatch):
+			m.jumpToMatch(true)
+		case key.Matches(msg, m.keys.ToggleMark):
+			m.toggleMark()
+		case key.Matches(msg, m.keys.VisualMode):
+			m.toggleVisual()
+		case key.Matches(msg, m.keys.SelectAll):
+			m.toggleSelectAll()
+		case key.Matches(msg, m.keys.EditTags):
+			return m, m.startTagEdit()
+		case key.Matches(msg, m.keys.EditProject):
+			return m, m.startProjectEdit()
+		case key.Matches(msg, m.keys.SetStatus):
+			return m, m.startStatusEdit()
 		case key.Matches(msg, m.keys.Escape):
+			if m.hasSelection() {
+				m.clearSelection()
+				break
+			}
+			if m.searchQuery != "" {
+				m.searchQuery = ""
+				break
+			}
 			m.mode = ModeNormal
 			return m, nil
 		}
@@ -293,36 +346,26 @@ func (m *Model) addingTaskUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.newTaskNameInput.Reset()
 			return m, nil
 		case key.Matches(msg, m.keys.Enter):
-			taskName := SanitizeTaskName(m.newTaskNameInput.Value())
-			if err := ValidateTaskName(taskName); err != nil {
-				// Could show error message here, for now just ignore invalid input
-				return m, nil
+			details, err := ParseQuickAdd(m.newTaskNameInput.Value(), time.Now())
+			if err != nil {
+				return m, m.showStatus(statusError, err.Error())
 			}
 
-			addedTask := m.taskManager.AddTask(taskName)
-			m.undoManager.PushUndo(task.Action{
-				Type: task.ActionTypeAdd,
-				Task: addedTask,
-			})
+			addedTask := m.taskManager.AddTaskWith(details)
+			m.undoManager.PushUndo(&task.AddCommand{Task: addedTask})
 
 			m.invalidateCache()
 			m.newTaskNameInput.Reset()
 			m.mode = ModeNormal
 			return m, nil
 		case key.Matches(msg, m.keys.Undo):
-			if m.undoManager.Undo(m.taskManager) {
-				m.invalidateCache()
-			}
 			m.mode = ModeNormal
 			m.newTaskNameInput.Reset()
-			return m, nil
+			return m, m.undo()
 		case key.Matches(msg, m.keys.Redo):
-			if m.undoManager.Redo(m.taskManager) {
-				m.invalidateCache()
-			}
 			m.mode = ModeNormal
 			m.newTaskNameInput.Reset()
-			return m, nil
+			return m, m.redo()
 		}
 	}
 
@@ -330,66 +373,263 @@ func (m *Model) addingTaskUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// editTaskUpdate handles updates in task editing mode.
-func (m *Model) editTaskUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+// blockedByUpdate handles updates in blocked-by editing mode.
+func (m *Model) blockedByUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.keys.Escape):
-			m.editTaskNameInput.Reset()
+			m.blockedByInput.Reset()
 			m.mode = ModeNormal
 			return m, nil
-		case key.Matches(msg, m.keys.Quit):
+		case key.Matches(msg, m.keys.Enter):
+			blockerIDs, err := ParseTaskIDs(m.blockedByInput.Value())
+			if err != nil {
+				return m, m.showStatus(statusError, err.Error())
+			}
+
+			m.updateTaskCache()
+			if m.cursor > 0 && m.cursor <= len(m.taskCache) {
+				taskToLink := m.taskCache[m.cursor-1]
+				oldBlockers := taskToLink.BlockedBy
+
+				if err := m.taskManager.SetDependencies(taskToLink.ID, blockerIDs); err != nil {
+					// Unknown tasks and cycles are rejected, keep the input open
+					return m, m.showStatus(statusError, err.Error())
+				}
+				m.undoManager.PushUndo(&task.DependencyCommand{
+					TaskID: taskToLink.ID,
+					Old:    oldBlockers,
+					New:    taskToLink.BlockedBy,
+				})
+				m.invalidateCache()
+				m.followTask(taskToLink.ID)
+			}
+
+			m.mode = ModeNormal
+			m.blockedByInput.Reset()
+			return m, nil
+		}
+	}
+
+	m.blockedByInput, cmd = m.blockedByInput.Update(msg)
+	return m, cmd
+}
+
+// recurrenceUpdate handles updates in recurrence editing mode.
+func (m *Model) recurrenceUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape):
+			m.recurrenceInput.Reset()
 			m.mode = ModeNormal
-			m.editTaskNameInput.Reset()
 			return m, nil
 		case key.Matches(msg, m.keys.Enter):
-			newName := SanitizeTaskName(m.editTaskNameInput.Value())
-			if err := ValidateTaskName(newName); err != nil {
-				// Could show error message here, for now just ignore invalid input
-				return m, nil
+			var recurrence *task.Recurrence
+			if rule := strings.TrimSpace(m.recurrenceInput.Value()); rule != "" {
+				parsed, err := task.ParseRecurrence(rule)
+				if err != nil {
+					return m, m.showStatus(statusError, err.Error())
+				}
+				recurrence = parsed
 			}
 
 			m.updateTaskCache()
 			if m.cursor > 0 && m.cursor <= len(m.taskCache) {
-				taskToEdit := m.taskCache[m.cursor-1]
-				oldName := taskToEdit.Name
-
-				if oldName != newName {
-					if updatedTask := m.taskManager.UpdateTaskName(taskToEdit.ID, newName); updatedTask != nil {
-						m.undoManager.PushUndo(task.Action{
-							Type:     task.ActionTypeEdit,
-							Task:     updatedTask,
-							OldState: oldName,
-							NewState: newName,
-						})
-					}
+				taskToRepeat := m.taskCache[m.cursor-1]
+				oldRecurrence := taskToRepeat.Recurrence
+
+				if updatedTask := m.taskManager.SetTaskRecurrence(taskToRepeat.ID, recurrence); updatedTask != nil {
+					m.undoManager.PushUndo(&task.RecurrenceCommand{
+						TaskID: updatedTask.ID,
+						Old:    oldRecurrence,
+						New:    recurrence,
+					})
 				}
 			}
 
 			m.mode = ModeNormal
-			m.editTaskNameInput.Reset()
+			m.recurrenceInput.Reset()
 			return m, nil
-		case key.Matches(msg, m.keys.Undo):
-			if m.undoManager.Undo(m.taskManager) {
-				m.invalidateCache()
+		}
+	}
+
+	m.recurrenceInput, cmd = m.recurrenceInput.Update(msg)
+	return m, cmd
+}
+
+// startTagEdit opens the tag prompt for the selected tasks, or for the task
+// under the cursor prefilled with its current tags.
+func (m *Model) startTagEdit() tea.Cmd {
+	targets := m.targetTasks()
+	if len(targets) == 0 {
+		return nil
+	}
+	m.tagsInput.SetValue("")
+	if !m.hasSelection() {
+		m.tagsInput.SetValue(task.FormatTags(targets[0].Tags))
+		m.tagsInput.CursorEnd()
+	}
+	m.promptListMode = m.mode
+	m.mode = ModeTags
+	return m.tagsInput.Focus()
+}
+
+// tagsUpdate handles updates in tag editing mode.
+func (m *Model) tagsUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape):
+			m.tagsInput.Reset()
+			m.mode = m.promptListMode
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			if err := m.setTasksTags(m.targetTasks(), m.tagsInput.Value()); err != nil {
+				return m, m.showStatus(statusError, err.Error())
 			}
-			m.mode = ModeNormal
-			m.editTaskNameInput.Reset()
+			m.mode = m.promptListMode
+			m.tagsInput.Reset()
 			return m, nil
-		case key.Matches(msg, m.keys.Redo):
-			if m.undoManager.Redo(m.taskManager) {
-				m.invalidateCache()
+		}
+	}
+
+	m.tagsInput, cmd = m.tagsInput.Update(msg)
+	return m, cmd
+}
+
+// startProjectEdit opens the project prompt for the selected tasks, or for
+// the task under the cursor prefilled with its current project.
+func (m *Model) startProjectEdit() tea.Cmd {
+	targets := m.targetTasks()
+	if len(targets) == 0 {
+		return nil
+	}
+	m.projectInput.SetValue("")
+	if !m.hasSelection() {
+		m.projectInput.SetValue(targets[0].Project)
+		m.projectInput.CursorEnd()
+	}
+	m.promptListMode = m.mode
+	m.mode = ModeProject
+	return m.projectInput.Focus()
+}
+
+// projectUpdate handles updates in project editing mode.
+func (m *Model) projectUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape):
+			m.projectInput.Reset()
+			m.mode = m.promptListMode
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			if err := m.setTasksProject(m.targetTasks(), m.projectInput.Value()); err != nil {
+				return m, m.showStatus(statusError, err.Error())
 			}
-			m.mode = ModeNormal
-			m.editTaskNameInput.Reset()
+			m.mode = m.promptListMode
+			m.projectInput.Reset()
 			return m, nil
 		}
 	}
 
-	m.editTaskNameInput, cmd = m.editTaskNameInput.Update(msg)
+	m.projectInput, cmd = m.projectInput.Update(msg)
+	return m, cmd
+}
+
+// startStatusEdit opens the status prompt for the selected tasks, or for the
+// task under the cursor prefilled with its current status.
+func (m *Model) startStatusEdit() tea.Cmd {
+	targets := m.targetTasks()
+	if len(targets) == 0 {
+		return nil
+	}
+	m.taskStatusInput.SetValue("")
+	if !m.hasSelection() {
+		m.taskStatusInput.SetValue(string(targets[0].CurrentStatus()))
+		m.taskStatusInput.CursorEnd()
+	}
+	m.promptListMode = m.mode
+	m.mode = ModeTaskStatus
+	return m.taskStatusInput.Focus()
+}
+
+// taskStatusUpdate handles updates in status editing mode.
+func (m *Model) taskStatusUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape):
+			m.taskStatusInput.Reset()
+			m.mode = m.promptListMode
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			status, err := task.ParseStatus(m.taskStatusInput.Value())
+			if err == nil {
+				err = m.setTasksStatus(m.targetTasks(), status)
+			}
+			if err != nil {
+				return m, m.showStatus(statusError, err.Error())
+			}
+			m.mode = m.promptListMode
+			m.taskStatusInput.Reset()
+			m.clampCursor(len(m.listTasks()))
+			return m, nil
+		}
+	}
+
+	m.taskStatusInput, cmd = m.taskStatusInput.Update(msg)
+	return m, cmd
+}
+
+// searchUpdate handles updates in search mode, moving the cursor to the best
+// match as the query is typed.
+func (m *Model) searchUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape):
+			m.cursor = m.searchOrigin
+			m.searchQuery = ""
+			m.searchInput.Reset()
+			m.mode = m.promptListMode
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			m.searchInput.Blur()
+			m.mode = m.promptListMode
+			return m, nil
+		case msg.Type == tea.KeyDown:
+			m.jumpToMatch(false)
+			return m, nil
+		case msg.Type == tea.KeyUp:
+			m.jumpToMatch(true)
+			return m, nil
+		}
+	}
+
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	if query := strings.TrimSpace(m.searchInput.Value()); query != m.searchQuery {
+		m.searchQuery = query
+		if query == "" {
+			m.cursor = m.searchOrigin
+		} else {
+			m.jumpToBestMatch()
+		}
+	}
 	return m, cmd
 }
 
@@ -398,9 +638,7 @@ func (m *Model) helpUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, m.keys.Quit):
-			m.mode = ModeNormal
-		case key.Matches(msg, m.keys.Escape):
+		case key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.Escape), key.Matches(msg, m.keys.Help):
 			m.mode = ModeNormal
 		}
 	}
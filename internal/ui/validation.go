@@ -2,8 +2,14 @@ package ui
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 	"unicode/utf8"
+
+	"github.com/voioo/td/internal/task"
 )
 
 // ValidateTaskName validates a task name input.
@@ -12,8 +18,8 @@ func ValidateTaskName(name string) error {
 	if name == "" {
 		return errors.New("task name cannot be empty")
 	}
-	if len(name) > 200 {
-		return errors.New("task name is too long (maximum 200 characters)")
+	if utf8.RuneCountInString(name) > task.MaxNameLength {
+		return fmt.Errorf("task name is too long (maximum %d characters)", task.MaxNameLength)
 	}
 	if !utf8.ValidString(name) {
 		return errors.New("task name contains invalid characters")
@@ -25,6 +31,17 @@ func ValidateTaskName(name string) error {
 	return nil
 }
 
+// ValidateTaskNotes validates the notes of a task.
+func ValidateTaskNotes(notes string) error {
+	if utf8.RuneCountInString(notes) > task.MaxNotesLength {
+		return fmt.Errorf("task notes are too long (maximum %d characters)", task.MaxNotesLength)
+	}
+	if !utf8.ValidString(notes) {
+		return errors.New("task notes contain invalid characters")
+	}
+	return nil
+}
+
 // ValidatePriorityInput validates priority input.
 func ValidatePriorityInput(priority int) error {
 	if priority < 0 || priority > 3 {
@@ -47,3 +64,193 @@ func SanitizeTaskName(name string) string {
 	}
 	return strings.TrimSpace(name)
 }
+
+// ParseQuickAdd parses the input of a new task, taking inline fields out of
+// the name:
+//
+//	!high, !!!   priority; also !medium or !!, !low or !
+//	#ops         tag
+//	+website     project
+//	due:fri      due date: today, tomorrow, a weekday, 2006-01-02, 3d or 2w
+//	every:week   recurrence rule, see task.ParseRecurrence
+//
+// Words that only look like a field stay part of the name, such as
+// "!important" or "#42" referring to an issue. Dates are relative to now.
+func ParseQuickAdd(input string, now time.Time) (task.Task, error) {
+	var details task.Task
+	var name []string
+	for _, word := range strings.Fields(SanitizeTaskName(input)) {
+		lower := strings.ToLower(word)
+		switch {
+		case strings.HasPrefix(lower, "due:"):
+			due, err := parseDueDate(lower[len("due:"):], now)
+			if err != nil {
+				return task.Task{}, err
+			}
+			details.DueAt = &due
+		case strings.HasPrefix(lower, "every:"):
+			recurrence, err := task.ParseRecurrence(lower[len("every:"):])
+			if err != nil {
+				return task.Task{}, err
+			}
+			details.Recurrence = recurrence
+		case strings.HasPrefix(word, "!"):
+			priority, ok := parseQuickPriority(lower[1:])
+			if !ok {
+				name = append(name, word)
+				continue
+			}
+			details.Priority = priority
+		case strings.HasPrefix(word, "#"), strings.HasPrefix(word, "+"):
+			value := lower[1:]
+			if !strings.ContainsFunc(value, unicode.IsLetter) {
+				name = append(name, word)
+				continue
+			}
+			if err := task.ValidateTag(value); err != nil {
+				return task.Task{}, err
+			}
+			if word[0] == '#' {
+				details.Tags = append(details.Tags, value)
+			} else {
+				details.Project = value
+			}
+		default:
+			name = append(name, word)
+		}
+	}
+
+	details.Name = strings.Join(name, " ")
+	if err := ValidateTaskName(details.Name); err != nil {
+		return task.Task{}, err
+	}
+	details.Tags = task.NormalizeTags(details.Tags)
+	return details, nil
+}
+
+// parseQuickPriority parses the priority of a "!" word without the first "!":
+// a priority name, or one or two more "!" for medium and high.
+func parseQuickPriority(value string) (task.Priority, bool) {
+	switch value {
+	case "":
+		return task.PriorityLow, true
+	case "!":
+		return task.PriorityMedium, true
+	case "!!":
+		return task.PriorityHigh, true
+	}
+	priority, err := task.ParsePriority(value)
+	return priority, err == nil
+}
+
+// parseDueDate parses a due date: today, tomorrow, the next day of the week
+// with the given name, a date such as 2006-01-02 or a number of days or weeks
+// from today such as 3d or 2w.
+func parseDueDate(value string, now time.Time) (time.Time, error) {
+	today := task.StartOfDay(now)
+	switch value {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		weekday := strings.ToLower(day.String())
+		if value == weekday || value == weekday[:3] {
+			days := (int(day)-int(today.Weekday())+6)%7 + 1
+			return today.AddDate(0, 0, days), nil
+		}
+	}
+
+	if len(value) > 1 {
+		if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n >= 0 {
+			switch value[len(value)-1] {
+			case 'd':
+				return today.AddDate(0, 0, n), nil
+			case 'w':
+				return today.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date %q, use today, tomorrow, a weekday, 2006-01-02, 3d or 2w", value)
+	}
+	return date, nil
+}
+
+// SanitizeTaskNotes normalizes line endings and trims surrounding blank lines
+// and trailing whitespace while keeping the notes multi-line.
+func SanitizeTaskNotes(notes string) string {
+	notes = strings.ReplaceAll(notes, "\r\n", "\n")
+	notes = strings.ReplaceAll(notes, "\r", "\n")
+	lines := strings.Split(notes, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// ParseTaskIDs parses a list of task IDs separated by commas or spaces.
+// Each ID may optionally be prefixed with '#'. An empty input yields no IDs.
+func ParseTaskIDs(input string) ([]int, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	ids := []int{}
+	for _, field := range fields {
+		id, err := strconv.Atoi(strings.TrimPrefix(field, "#"))
+		if err != nil || id <= 0 {
+			return nil, errors.New("task IDs must be positive numbers")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ParseTagEdit applies a tag edit to the current tags. Words prefixed with '+'
+// or '-' add or remove a tag, so "+ops -backend" edits the existing tags. When
+// any word has no prefix the input replaces the tags instead, and an empty
+// input clears them.
+func ParseTagEdit(current []string, input string) ([]string, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	replace := len(fields) == 0
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "+") && !strings.HasPrefix(field, "-") {
+			replace = true
+			break
+		}
+	}
+
+	tags := map[string]bool{}
+	if !replace {
+		for _, tag := range current {
+			tags[tag] = true
+		}
+	}
+	for _, field := range fields {
+		remove := strings.HasPrefix(field, "-")
+		if remove || strings.HasPrefix(field, "+") {
+			field = field[1:]
+		}
+		tag := task.NormalizeTag(field)
+		if err := task.ValidateTag(tag); err != nil {
+			return nil, err
+		}
+		tags[tag] = !remove
+	}
+
+	result := []string{}
+	for tag, keep := range tags {
+		if keep {
+			result = append(result, tag)
+		}
+	}
+	return task.NormalizeTags(result), nil
+}
@@ -2,7 +2,14 @@ package ui
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
 )
 
 func TestValidateTaskName(t *testing.T) {
@@ -86,6 +93,170 @@ func TestValidatePriorityInput(t *testing.T) {
 	}
 }
 
+func TestSanitizeTaskNotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"keeps newlines", "line one\nline two", "line one\nline two"},
+		{"normalizes CRLF", "line one\r\nline two\r\n", "line one\nline two"},
+		{"trims trailing whitespace", "item  \n  indented\t", "item\n  indented"},
+		{"trims blank lines", "\n\nnotes\n\n", "notes"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := SanitizeTaskNotes(test.input)
+			if result != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateTaskNotes(t *testing.T) {
+	if err := ValidateTaskNotes("# Plan\n- step one"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ValidateTaskNotes(strings.Repeat("x", task.MaxNotesLength+1)); err == nil {
+		t.Error("expected error for notes that are too long")
+	}
+	// The limit counts characters, not bytes
+	if err := ValidateTaskNotes(strings.Repeat("é", task.MaxNotesLength)); err != nil {
+		t.Errorf("expected no error for notes at the limit, got %v", err)
+	}
+}
+
+func TestParseTaskIDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []int
+		expectErr bool
+	}{
+		{"empty", "", []int{}, false},
+		{"single id", "3", []int{3}, false},
+		{"hash prefixes", "#3, #7", []int{3, 7}, false},
+		{"spaces", "3 7  9", []int{3, 7, 9}, false},
+		{"not a number", "abc", nil, true},
+		{"zero id", "0", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ids, err := ParseTaskIDs(test.input)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(ids) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != test.expected[i] {
+					t.Errorf("expected %v, got %v", test.expected, ids)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTagEdit(t *testing.T) {
+	current := []string{"backend", "ops"}
+	tests := []struct {
+		name      string
+		input     string
+		expected  string
+		expectErr bool
+	}{
+		{"replace", "Frontend #ui", "#frontend #ui", false},
+		{"add", "+urgent", "#backend #ops #urgent", false},
+		{"remove", "-ops", "#backend", false},
+		{"add and remove", "+ui, -backend", "#ops #ui", false},
+		{"mixed replaces", "ui -ops", "#ui", false},
+		{"empty clears", "  ", "", false},
+		{"invalid tag", "+a!b", "", true},
+		{"bare prefix", "+", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tags, err := ParseTagEdit(current, test.input)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", tags)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got := task.FormatTags(tags); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseQuickAdd(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local) // A Wednesday
+	day := func(month time.Month, d int) string {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string // name|priority|project|tags|due|recurrence
+	}{
+		{"plain name", "  buy   milk ", "buy milk|none||||"},
+		{"all fields", "deploy !high #ops +Website due:2026-11-03 every:week #api", "deploy|high|website|#api #ops|2026-11-03|every week"},
+		{"bangs", "call mom !!!", "call mom|high||||"},
+		{"single bang", "! stretch", "stretch|low||||"},
+		{"fields anywhere", "#home clean !!", "clean|medium||#home||"},
+		{"today", "pay rent due:today", "pay rent|none|||" + day(10, 14) + "|"},
+		{"tomorrow", "pay rent due:Tomorrow", "pay rent|none|||" + day(10, 15) + "|"},
+		{"later weekday", "review due:fri", "review|none|||" + day(10, 16) + "|"},
+		{"same weekday", "review due:wednesday", "review|none|||" + day(10, 21) + "|"},
+		{"days", "review due:3d", "review|none|||" + day(10, 17) + "|"},
+		{"weeks", "review due:2w", "review|none|||" + day(10, 28) + "|"},
+		{"not a field", "fix #42 !important +1", "fix #42 !important +1|none||||"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			details, err := ParseQuickAdd(test.input, now)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			due, recurrence := "", ""
+			if details.DueAt != nil {
+				due = details.DueAt.Format("2006-01-02")
+			}
+			if details.Recurrence != nil {
+				recurrence = details.Recurrence.String()
+			}
+			got := strings.Join([]string{details.Name, details.Priority.String(), details.Project,
+				task.FormatTags(details.Tags), due, recurrence}, "|")
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "!high #ops", "x due:someday", "x due:", "x every:never", "x #a!b"} {
+		if _, err := ParseQuickAdd(input, now); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
 // Helper functions to create expected errors
 func errTaskNameEmpty() error {
 	return errors.New("task name cannot be empty")
@@ -102,3 +273,31 @@ func errTaskNameInvalidChars() error {
 func errInvalidPriority() error {
 	return errors.New("priority must be between 0 and 3")
 }
+
+func TestQuickAddMode(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	m := NewModel(config.DefaultConfig(), tm)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	for _, r := range "deploy !high +web #ops due:2026-11-03" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	view := m.View()
+	for _, field := range []string{"name: deploy", "priority: high", "project: +web", "tags: #ops", "due: Tue 2026-11-03"} {
+		if !strings.Contains(view, field) {
+			t.Errorf("expected %q in the preview, got:\n%s", field, view)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	tasks := tm.GetTasks()
+	if m.mode != ModeNormal || len(tasks) != 1 {
+		t.Fatalf("expected the task to be added, got %d tasks", len(tasks))
+	}
+	if added := tasks[0]; added.Name != "deploy" || added.Priority != task.PriorityHigh || added.Project != "web" {
+		t.Errorf("expected the parsed fields, got %+v", added)
+	}
+	if m.undoManager.Undo(tm); len(tm.GetTasks()) != 0 {
+		t.Error("expected the addition to be undoable")
+	}
+}
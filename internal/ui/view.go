@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
@@ -21,17 +22,25 @@ func (m *Model) normalView() string {
 	var title termenv.Style
 	var tasksToDisplay []*task.Task
 
-	switch m.mode {
+	switch m.listMode() {
 	case ModeNormal:
 		if len(m.taskManager.GetTasks()) == 0 {
-			helpView := m.help.FullHelpView(m.keys.FullHelp())
-			return "You have no tasks.\n" + helpView
+			return "You have no tasks.\n" + m.listHelpView()
 		}
 		titleStr := "YOUR TASKS"
+		if view := m.activeView(); view != nil {
+			titleStr += fmt.Sprintf(" (view: %s)", view.name)
+		}
 		if m.filter != FilterAll {
 			filterName := m.filterModeName()
 			titleStr += fmt.Sprintf(" (filtered: %s)", filterName)
 		}
+		if order := m.sortOrderName(); order != "" {
+			titleStr += fmt.Sprintf(" (sorted: %s)", order)
+		}
+		if m.groupMode != task.GroupNone {
+			titleStr += fmt.Sprintf(" (grouped: %s)", m.groupMode)
+		}
 		title = termenv.String(titleStr)
 		m.updateTaskCache()
 		tasksToDisplay = m.taskCache
@@ -40,42 +49,211 @@ func (m *Model) normalView() string {
 		if len(doneTasks) == 0 {
 			return "You have no completed tasks.\n"
 		}
-		title = termenv.String("YOUR COMPLETED TASKS")
+		titleStr := "YOUR COMPLETED TASKS"
+		if cancelled := countCancelled(doneTasks); cancelled > 0 {
+			titleStr += fmt.Sprintf(" (%d done, %d cancelled)", len(doneTasks)-cancelled, cancelled)
+		}
+		title = termenv.String(titleStr)
 		tasksToDisplay = doneTasks
 	}
+	if selected := len(m.selectedTasks()); selected > 0 {
+		title = termenv.String(fmt.Sprintf("%s (%d selected)", title.String(), selected))
+	}
 
+	var list strings.Builder
 	title = title.Bold().Underline()
-	s.WriteString(fmt.Sprintf("%v\n\n", title))
+	list.WriteString(fmt.Sprintf("%v%s\n\n", title, m.timerHeader()))
 
-	for i, task := range tasksToDisplay {
+	// Only render the rows that fit on screen, keeping the cursor visible
+	rows := m.visibleRows()
+	listRows := m.listRows(tasksToDisplay)
+	m.scrollToListCursor(rows, listRows)
+	start, end := 0, len(listRows)
+	if rows > 0 && end > rows {
+		start, end = m.offset, m.offset+rows
+	}
+
+	first, last := 0, 0
+	for _, row := range listRows[start:end] {
+		if row.index < 0 {
+			header := m.inputStyle.Bold(true).Render(row.header)
+			if m.width > 0 {
+				header = lipgloss.NewStyle().MaxWidth(m.width).Render(header)
+			}
+			list.WriteString(header + "\n")
+			continue
+		}
+		i := row.index
+		if first == 0 {
+			first = i + 1
+		}
+		last = i + 1
+		task := tasksToDisplay[i]
 		cursor := termenv.String(" ")
 		if m.cursor == i+1 {
 			cursor = termenv.String(">").Foreground(termenv.ANSIYellow)
 		}
+		mark := " "
+		if m.isSelected(task, i) {
+			mark = m.inputStyle.Render("*")
+		}
 
 		taskStr := m.taskView(task, m.cursor == i+1)
 		timeLayout := "2006-01-02 15:04"
-		s.WriteString(fmt.Sprintf("%v #%d: %s (%s)\n", cursor, task.ID, taskStr, task.CreatedAt.Format(timeLayout)))
+		date := task.CreatedAt.Format(timeLayout)
+		if task.IsDone {
+			date = closedDate(task, timeLayout)
+		}
+		line := fmt.Sprintf("%v%s#%d: %s (%s)", cursor, mark, task.ID, taskStr, date)
+		if m.width > 0 {
+			// Keep one row per task so the viewport arithmetic holds
+			line = lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+		}
+		list.WriteString(line + "\n")
+	}
+	if indicator := scrollIndicator(first, last, len(tasksToDisplay)); indicator != "" {
+		list.WriteString(indicator + "\n")
+	}
+	if line := m.searchLine(); line != "" {
+		list.WriteString(line + "\n")
+	}
+
+	if m.showDetail {
+		s.WriteString(m.withDetailPane(list.String()))
+	} else {
+		s.WriteString(list.String())
 	}
 
-	helpView := m.help.FullHelpView(m.keys.FullHelp())
 	height := 1
 	s.WriteString("\n" + strings.Repeat("\n", height))
-	s.WriteString(helpView)
+	s.WriteString(m.listHelpView())
 
 	return s.String()
 }
 
-// addingTaskView renders the task adding view.
+// closedDate describes when a completed or cancelled task was closed.
+func closedDate(t *task.Task, layout string) string {
+	verb := "completed"
+	if t.IsCancelled() {
+		verb = "cancelled"
+	}
+	if closedAt := t.ClosedAt(); closedAt != nil {
+		return verb + " " + closedAt.Format(layout)
+	}
+	return verb
+}
+
+// countCancelled returns how many of the tasks were cancelled.
+func countCancelled(tasks []*task.Task) int {
+	cancelled := 0
+	for _, t := range tasks {
+		if t.IsCancelled() {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// addingTaskView renders the task adding view with a preview of the fields
+// parsed from the input.
 func (m *Model) addingTaskView() string {
 	title := termenv.String("Additional Mode").Bold().Underline()
-	return fmt.Sprintf("%v\n\nInput the new task name\n\n%s\n", title, m.newTaskNameInput.View())
+	view := fmt.Sprintf("%v\n\nInput the new task name\n"+
+		"add fields inline: !high or !!!, #tag, +project, due:fri, every:week\n\n%s\n",
+		title, m.newTaskNameInput.View())
+	if preview := m.quickAddPreview(m.newTaskNameInput.Value()); preview != "" {
+		view += "\n" + preview + "\n"
+	}
+	return view
+}
+
+// quickAddPreview describes the task that would be added for the input, or
+// why it cannot be added.
+func (m *Model) quickAddPreview(input string) string {
+	if strings.TrimSpace(input) == "" {
+		return ""
+	}
+	details, err := ParseQuickAdd(input, time.Now())
+	if err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.HighPriorityColor))
+		return errStyle.Render(err.Error())
+	}
+
+	fields := []string{"name: " + details.Name}
+	if details.Priority != task.PriorityNone {
+		fields = append(fields, "priority: "+details.Priority.String())
+	}
+	if details.Project != "" {
+		fields = append(fields, "project: "+task.FormatProject(details.Project))
+	}
+	if len(details.Tags) > 0 {
+		fields = append(fields, "tags: "+task.FormatTags(details.Tags))
+	}
+	if details.DueAt != nil {
+		fields = append(fields, "due: "+details.DueAt.Format("Mon 2006-01-02"))
+	}
+	if details.Recurrence != nil {
+		fields = append(fields, "recurrence: "+details.Recurrence.String())
+	}
+	return mutedStyle.Render(strings.Join(fields, " · "))
+}
+
+// blockedByView renders the blocked-by editing view.
+func (m *Model) blockedByView() string {
+	title := termenv.String("Dependency Mode").Bold().Underline()
+	prompt := "Input the IDs of the tasks blocking this task (empty to clear)"
+	if t := m.getCurrentTask(); t != nil {
+		prompt = fmt.Sprintf("Input the IDs of the tasks blocking #%d (empty to clear)", t.ID)
+	}
+	return fmt.Sprintf("%v\n\n%s\n\n%s\n", title, prompt, m.blockedByInput.View())
+}
+
+// recurrenceView renders the recurrence editing view.
+func (m *Model) recurrenceView() string {
+	title := termenv.String("Recurrence Mode").Bold().Underline()
+	current := "does not repeat"
+	if t := m.getCurrentTask(); t != nil && t.Recurrence != nil {
+		current = "repeats " + t.Recurrence.String()
+	}
+	return fmt.Sprintf("%v\n\nInput a recurrence rule (currently %s, empty to clear)\n"+
+		"daily, weekly, monthly, weekdays, 3d, 2w, 1m or mon,wed,fri; append ! to repeat after completion\n\n%s\n",
+		title, current, m.recurrenceInput.View())
+}
+
+// tagsView renders the tag editing view.
+func (m *Model) tagsView() string {
+	title := termenv.String("Tag Mode").Bold().Underline()
+	prompt := "Input the tags of the task (empty to clear)"
+	if selected := m.selectedTasks(); len(selected) > 0 {
+		prompt = fmt.Sprintf("Edit the tags of %d selected tasks: +tag adds, -tag removes, plain tags replace them", len(selected))
+	} else if t := m.selectedTask(); t != nil {
+		prompt = fmt.Sprintf("Input the tags of #%d (empty to clear, +tag/-tag to add or remove)", t.ID)
+	}
+	return fmt.Sprintf("%v\n\n%s\n\n%s\n", title, prompt, m.tagsInput.View())
 }
 
-// editTaskView renders the task editing view.
-func (m *Model) editTaskView() string {
-	title := termenv.String("Edit Mode").Bold().Underline()
-	return fmt.Sprintf("%v\n\nInput the new task name\n\n%s\n", title, m.editTaskNameInput.View())
+// projectView renders the project editing view.
+func (m *Model) projectView() string {
+	title := termenv.String("Project Mode").Bold().Underline()
+	prompt := "Input the project of the task (empty to clear)"
+	if selected := m.selectedTasks(); len(selected) > 0 {
+		prompt = fmt.Sprintf("Input the project of %d selected tasks (empty to clear)", len(selected))
+	} else if t := m.selectedTask(); t != nil {
+		prompt = fmt.Sprintf("Input the project of #%d (empty to clear)", t.ID)
+	}
+	return fmt.Sprintf("%v\n\n%s\n\n%s\n", title, prompt, m.projectInput.View())
+}
+
+// taskStatusView renders the status editing view.
+func (m *Model) taskStatusView() string {
+	title := termenv.String("Status Mode").Bold().Underline()
+	prompt := "Input the status of the task"
+	if selected := m.selectedTasks(); len(selected) > 0 {
+		prompt = fmt.Sprintf("Input the status of %d selected tasks", len(selected))
+	} else if t := m.selectedTask(); t != nil {
+		prompt = fmt.Sprintf("Input the status of #%d", t.ID)
+	}
+	return fmt.Sprintf("%v\n\n%s\n\n%s\n", title, prompt, m.taskStatusInput.View())
 }
 
 // helpView renders the help view.
@@ -102,150 +280,176 @@ func (m *Model) taskView(t *task.Task, selected bool) string {
 	}
 	sb.WriteString(priorityStr + " ")
 
-	// Task name with selection
-	taskName := t.Name
+	// Task name with selection, dimmed while blocked by unfinished tasks
+	var blockers []*task.Task
+	if !t.IsDone {
+		blockers = m.taskManager.Blockers(t.ID)
+	}
+	nameStyle := lipgloss.NewStyle()
 	if selected {
-		taskName = m.inputStyle.Render(taskName)
+		nameStyle = m.inputStyle
+	} else if len(blockers) > 0 || t.IsCancelled() {
+		nameStyle = mutedStyle
+	}
+	if t.IsCancelled() {
+		nameStyle = nameStyle.Strikethrough(true)
+	}
+	var matched []int
+	if m.searchQuery != "" {
+		_, matched, _ = matchTask(m.searchQuery, t)
+	}
+	sb.WriteString(highlightRunes(t.Name, matched, nameStyle))
+
+	if t.Project != "" {
+		sb.WriteString(mutedStyle.Render(" " + task.FormatProject(t.Project)))
+	}
+	if len(t.Tags) > 0 {
+		sb.WriteString(mutedStyle.Render(" " + task.FormatTags(t.Tags)))
+	}
+
+	if symbol, ok := statusSymbols[t.CurrentStatus()]; ok {
+		sb.WriteString(m.statusStyle(t.CurrentStatus()).Render(fmt.Sprintf(" %s %s", symbol, strings.ToLower(t.CurrentStatus().Label()))))
+	}
+
+	if len(blockers) > 0 {
+		ids := make([]int, len(blockers))
+		for i, blocker := range blockers {
+			ids[i] = blocker.ID
+		}
+		sb.WriteString(mutedStyle.Render(" ⊘ blocked by " + task.FormatIDs(ids)))
+	}
+
+	if t.DueAt != nil {
+		dueStr := " due " + t.DueAt.Format("2006-01-02")
+		if !t.IsDone && t.DueAt.Before(task.StartOfDay(time.Now())) {
+			sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.HighPriorityColor)).Render(dueStr))
+		} else {
+			sb.WriteString(mutedStyle.Render(dueStr))
+		}
+	}
+	if t.Recurrence != nil {
+		sb.WriteString(mutedStyle.Render(" ↻ " + t.Recurrence.String()))
+	}
+	if t.Notes != "" {
+		sb.WriteString(mutedStyle.Render(" ✎"))
+	}
+	if t.RunningEntry() != nil {
+		sb.WriteString(m.inputStyle.Render(" ⏱"))
 	}
-	sb.WriteString(taskName)
 
 	return sb.String()
 }
 
+// statusSymbols mark the tasks whose status is worth pointing out in the list.
+var statusSymbols = map[task.Status]string{
+	task.StatusInProgress: "◐",
+	task.StatusWaiting:    "⧗",
+	task.StatusBlocked:    "⊘",
+	task.StatusCancelled:  "✗",
+}
+
+// statusStyle renders a status in its theme color.
+func (m *Model) statusStyle(status task.Status) lipgloss.Style {
+	color, ok := m.config.Theme.StatusColors[string(status)]
+	if !ok {
+		return mutedStyle
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
+// mutedStyle renders secondary task information such as blockers and due dates.
+var mutedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+
+// Usage view styles
+var (
+	usageHeaderStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#4CAF50"))
+	usageDividerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+	usageKeyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#90CAF9"))
+	usageDescStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+)
+
+// usageHeader renders a section header with an underline divider.
+func usageHeader(title string) string {
+	return lipgloss.JoinVertical(lipgloss.Left,
+		usageHeaderStyle.Render(title),
+		usageDividerStyle.Render(strings.Repeat("─", lipgloss.Width(title))),
+	)
+}
+
+// usageEntry renders a single key and its description.
+func usageEntry(keys, description string) string {
+	return usageKeyStyle.Render(fmt.Sprintf("  • %-7s", keys)) + usageDescStyle.Render(" "+description)
+}
+
+// usagePriority renders a priority level legend entry.
+func usagePriority(color, symbol, keys, description string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("  • "+symbol+" ") +
+		usageDescStyle.Render(fmt.Sprintf("%-5s%s", keys, description))
+}
+
 // getUsageView returns the usage help text.
 func getUsageView(config *config.Config) string {
 	leftColumn := lipgloss.JoinVertical(lipgloss.Left,
-		lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#4CAF50")).
-			Render("Task Management"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("───────────────"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • "+config.KeyMap.Add+"     ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" add new task"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • "+config.KeyMap.Delete+"     ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" delete task"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • "+config.KeyMap.Enter+" ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" mark done/undone"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • e/→    ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" edit task name"),
+		usageHeader("Task Management"),
+		usageEntry(config.KeyMap.Add, "add new task"),
+		usageEntry(config.KeyMap.Delete, "delete task"),
+		usageEntry(config.KeyMap.Enter, "mark done/undone"),
+		usageEntry("e/→", "edit task name"),
+		usageEntry("b", "set blocking tasks"),
+		usageEntry("R", "set recurrence"),
+		usageEntry("E", "edit notes in $EDITOR"),
+		usageEntry("i", "show/hide details"),
+		usageEntry("#", "edit tags"),
+		usageEntry("P", "set project"),
+		usageEntry("x", "set status"),
+		usageEntry("T", "start/stop timer"),
 		"",
-		lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#4CAF50")).
-			Render("Navigation"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("──────────"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • ↑/k   ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" move up"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • ↓/j   ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" move down"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • "+config.KeyMap.ListType+"     ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" toggle tasks view"),
+		usageHeader("Navigation"),
+		usageEntry("↑/k", "move up"),
+		usageEntry("↓/j", "move down"),
+		usageEntry(config.KeyMap.ListType, "toggle tasks view"),
+		usageEntry("B", "board, H/L move cards"),
+		usageEntry("A", "agenda of the week ahead"),
+		usageEntry("M", "month calendar"),
+		usageEntry("I", "statistics"),
+		usageEntry("/", "search tasks"),
+		usageEntry("n/N", "next/previous match"),
+		usageEntry("J/K", "move task down/up"),
+		usageEntry("s", "change sort order"),
+		usageEntry("S", "reverse sort order"),
+		usageEntry("o", "group by priority/project/tag/due"),
 		"",
-		lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#4CAF50")).
-			Render("General"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("───────"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • "+config.KeyMap.Help+"     ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" show/hide help"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • "+config.KeyMap.Quit+"   ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" quit"),
+		usageHeader("General"),
+		usageEntry(config.KeyMap.Help, "show/hide help"),
+		usageEntry(config.KeyMap.Undo+"/"+config.KeyMap.Redo, "undo/redo"),
+		usageEntry("U", "browse undo history"),
+		usageEntry(config.KeyMap.Quit, "quit"),
 	)
 
 	rightColumn := lipgloss.JoinVertical(lipgloss.Left,
-		lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#4CAF50")).
-			Render("Priority Management"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("──────────────────"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • 1-4   ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" set priority directly"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • "+config.KeyMap.Filter+"    ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" filter by priority"),
+		usageHeader("Priority Management"),
+		usageEntry("1-4", "set priority directly"),
+		usageEntry(config.KeyMap.Filter, "filter by priority or readiness"),
+		usageEntry("F", "filter by query"),
+		usageEntry("v", "pick a saved view"),
+		usageEntry("alt+1-9", "switch to view, alt+0 all tasks"),
+		"",
+		usageHeader("Priority Levels"),
+		usagePriority(config.Theme.HighPriorityColor, "●", "4", "high priority"),
+		usagePriority(config.Theme.MediumPriorityColor, "●", "3", "medium priority"),
+		usagePriority(config.Theme.LowPriorityColor, "●", "2", "low priority"),
+		usagePriority("#666666", "○", "1", "no priority"),
 		"",
-		lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#4CAF50")).
-			Render("Priority Levels"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("──────────────"),
-		lipgloss.NewStyle().Foreground(lipgloss.Color(config.Theme.HighPriorityColor)).Render("  • ● ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render("4    high priority"),
-		lipgloss.NewStyle().Foreground(lipgloss.Color(config.Theme.MediumPriorityColor)).Render("  • ● ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render("3    medium priority"),
-		lipgloss.NewStyle().Foreground(lipgloss.Color(config.Theme.LowPriorityColor)).Render("  • ● ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render("2    low priority"),
-		lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("  • ○ ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render("1    no priority"),
+		usageHeader("Navigation"),
+		usageEntry("home/g", "go to top"),
+		usageEntry("end/G", "go to bottom"),
+		usageEntry("C", "clear completed"),
 		"",
-		lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#4CAF50")).
-			Render("Navigation"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("──────────"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • home/g ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" go to top"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • end/G  ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" go to bottom"),
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#90CAF9")).
-			Render("  • C      ")+lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Render(" clear completed"),
+		usageHeader("Selection"),
+		usageEntry("space", "mark/unmark task"),
+		usageEntry("V", "start/mark range"),
+		usageEntry("ctrl+a", "mark all shown"),
+		usageEntry("esc", "clear marks"),
 	)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top,
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/voioo/td/internal/task"
+)
+
+// listChromeLines is the number of lines around the task list that are not
+// list rows: the title and its blank line, the scroll indicator, and the two
+// blank lines before the help.
+const listChromeLines = 5
+
+// listRow is a row of the task list: either a task or a group header.
+type listRow struct {
+	header string
+	index  int // Index of the task in the list, -1 for a header
+}
+
+// listRows lays out tasks as rows, with a header above each group when the
+// active list is grouped.
+func (m *Model) listRows(tasks []*task.Task) []listRow {
+	var rows []listRow
+	if m.listMode() != ModeNormal || m.groupMode == task.GroupNone {
+		rows = make([]listRow, len(tasks))
+		for i := range tasks {
+			rows[i] = listRow{index: i}
+		}
+		return rows
+	}
+
+	index := 0
+	for _, group := range m.taskGroups {
+		rows = append(rows, listRow{header: group.Name, index: -1})
+		for range group.Tasks {
+			rows = append(rows, listRow{index: index})
+			index++
+		}
+	}
+	return rows
+}
+
+// scrollToListCursor adjusts the scroll offset so the row of the cursor stays
+// within the visible window, together with the header of its group when it is
+// the first task of the group.
+func (m *Model) scrollToListCursor(visible int, rows []listRow) {
+	row := 0
+	for i, r := range rows {
+		if r.index == m.cursor-1 {
+			row = i
+			break
+		}
+	}
+	m.scrollToRow(visible, len(rows), row)
+	if visible > 1 && row > 0 && row == m.offset && rows[row-1].index < 0 {
+		m.offset--
+	}
+}
+
+// listHelpView renders the key help shown below the task list.
+func (m *Model) listHelpView() string {
+	return m.help.ShortHelpView(m.keys.ShortHelp())
+}
+
+// visibleRows returns how many list rows fit on screen, or zero when the
+// terminal size is not known yet and the whole list should be rendered.
+func (m *Model) visibleRows() int {
+	if m.height <= 0 {
+		return 0
+	}
+
+	reserved := listChromeLines + lipgloss.Height(m.listHelpView())
+	if m.searchLine() != "" {
+		reserved++
+	}
+	if m.statusLine() != "" {
+		reserved++
+	}
+	if m.showDetail && m.width < DetailPaneMinSplitWidth {
+		reserved += m.bottomDetailHeight()
+	}
+
+	rows := m.height - reserved
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// bottomDetailHeight returns the number of lines given to the details pane
+// when it is stacked below the list.
+func (m *Model) bottomDetailHeight() int {
+	return m.height / 2
+}
+
+// scrollToRow adjusts the scroll offset so the row at index stays within the
+// visible window of rows out of total rows.
+func (m *Model) scrollToRow(rows, total, index int) {
+	m.offset = scrollOffset(m.offset, rows, total, index)
+}
+
+// scrollOffset returns the scroll offset closest to offset that keeps the row
+// at index within the visible window of rows out of total rows.
+func scrollOffset(offset, rows, total, index int) int {
+	if rows <= 0 || total <= rows {
+		return 0
+	}
+
+	if index < 0 {
+		index = 0
+	}
+	if index < offset {
+		offset = index
+	}
+	if index >= offset+rows {
+		offset = index - rows + 1
+	}
+	if offset > total-rows {
+		offset = total - rows
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// moveCursor moves the cursor by delta rows, clamped to the list of total tasks.
+func (m *Model) moveCursor(delta, total int) {
+	if total == 0 {
+		m.cursor = 0
+		return
+	}
+	m.cursor += delta
+	if m.cursor < 1 {
+		m.cursor = 1
+	}
+	if m.cursor > total {
+		m.cursor = total
+	}
+}
+
+// pageSize returns the number of rows moved by a page jump.
+func (m *Model) pageSize() int {
+	if rows := m.visibleRows(); rows > 0 {
+		return rows
+	}
+	return DefaultPageSize
+}
+
+// scrollIndicator describes which tasks of the list are visible, from first
+// to last counting from one, or returns an empty string when every task fits
+// on screen.
+func scrollIndicator(first, last, total int) string {
+	if first <= 1 && last >= total {
+		return ""
+	}
+	indicator := fmt.Sprintf("%d-%d of %d", first, last, total)
+	if first > 1 {
+		indicator = "↑ " + indicator
+	}
+	if last < total {
+		indicator += " ↓"
+	}
+	return mutedStyle.Render(indicator)
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestScrollToRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   int
+		offset   int
+		rows     int
+		total    int
+		expected int
+	}{
+		{"everything fits", 5, 3, 10, 8, 0},
+		{"unknown height", 40, 3, 0, 64, 0},
+		{"cursor below window", 25, 0, 10, 64, 15},
+		{"cursor above window", 3, 20, 10, 64, 2},
+		{"cursor inside window", 25, 20, 10, 64, 20},
+		{"offset past end", 64, 60, 10, 64, 54},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &Model{offset: test.offset}
+			m.scrollToRow(test.rows, test.total, test.cursor-1)
+			if m.offset != test.expected {
+				t.Errorf("expected offset %d, got %d", test.expected, m.offset)
+			}
+		})
+	}
+}
+
+func TestMoveCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   int
+		delta    int
+		total    int
+		expected int
+	}{
+		{"page down", 1, 10, 64, 11},
+		{"clamped at bottom", 60, 10, 64, 64},
+		{"clamped at top", 4, -10, 64, 1},
+		{"empty list", 3, 10, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &Model{cursor: test.cursor}
+			m.moveCursor(test.delta, test.total)
+			if m.cursor != test.expected {
+				t.Errorf("expected cursor %d, got %d", test.expected, m.cursor)
+			}
+		})
+	}
+}
+
+func TestScrollIndicator(t *testing.T) {
+	indicator := scrollIndicator(21, 30, 64)
+	for _, want := range []string{"↑", "21-30 of 64", "↓"} {
+		if !strings.Contains(indicator, want) {
+			t.Errorf("expected indicator %q to contain %q", indicator, want)
+		}
+	}
+	if scrollIndicator(1, 8, 8) != "" {
+		t.Error("expected no indicator when every task fits")
+	}
+}
+
+func TestListFitsSmallTerminal(t *testing.T) {
+	names := make([]string, 30)
+	for i := range names {
+		names[i] = fmt.Sprintf("task %d", i+1)
+	}
+	m := newSelectionModel(t, names...)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	// Only the short help is shown below the list, on a single line
+	if rows := m.visibleRows(); rows != 24-listChromeLines-1 {
+		t.Errorf("expected %d rows on an 80x24 terminal, got %d", 24-listChromeLines-1, rows)
+	}
+	view := m.View()
+	if lines := strings.Count(view, "\n") + 1; lines > 24 {
+		t.Errorf("expected the view to fit in 24 lines, got %d:\n%s", lines, view)
+	}
+	if !strings.Contains(view, "? all keys") {
+		t.Errorf("expected the short help to point to the usage screen, got:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if m.mode != ModeHelp {
+		t.Fatalf("expected the usage screen, got mode %v", m.mode)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if m.mode != ModeNormal {
+		t.Errorf("expected ? to close the usage screen, got mode %v", m.mode)
+	}
+}
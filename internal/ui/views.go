@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/logger"
+	"github.com/voioo/td/internal/query"
+	"github.com/voioo/td/internal/task"
+)
+
+// savedView is a view from the config with its filter parsed.
+type savedView struct {
+	name    string
+	filter  *query.Query
+	sort    task.SortMode  // Empty to keep the current sort mode
+	reverse bool           // Only used together with sort
+	group   task.GroupMode // Empty to keep the current grouping
+}
+
+// loadViews parses the views of the config. Views that cannot be parsed are
+// skipped with a warning.
+func loadViews(views []config.View) []savedView {
+	var loaded []savedView
+	for _, v := range views {
+		view, err := parseView(v)
+		if err != nil {
+			logger.Warn("Skipping invalid view", logger.F("view", v.Name), logger.F("error", err))
+			continue
+		}
+		loaded = append(loaded, view)
+	}
+	return loaded
+}
+
+// parseView parses a single view of the config.
+func parseView(v config.View) (savedView, error) {
+	view := savedView{name: strings.TrimSpace(v.Name), reverse: v.Reverse}
+	if view.name == "" {
+		return savedView{}, fmt.Errorf("view has no name")
+	}
+	var err error
+	if view.filter, err = query.Parse(v.Filter); err != nil {
+		return savedView{}, err
+	}
+	if v.Sort != "" {
+		if view.sort, err = task.ParseSortMode(v.Sort); err != nil {
+			return savedView{}, err
+		}
+	}
+	if v.Group != "" {
+		if view.group, err = task.ParseGroupMode(v.Group); err != nil {
+			return savedView{}, err
+		}
+	}
+	return view, nil
+}
+
+// activeView returns the view the list is shown in, or nil for all tasks.
+func (m *Model) activeView() *savedView {
+	if m.viewIndex < 0 || m.viewIndex >= len(m.views) {
+		return nil
+	}
+	return &m.views[m.viewIndex]
+}
+
+// applyView switches the list to the view at index, or back to all tasks in
+// the configured order for an index out of range. The cursor stays on the
+// same task when it is part of the view.
+func (m *Model) applyView(index int) {
+	if index >= len(m.views) {
+		return
+	}
+	current := m.getCurrentTask()
+	m.viewIndex = index
+	m.filter, m.query = FilterAll, nil
+	m.clearSelection()
+
+	if view := m.activeView(); view != nil {
+		if view.sort != "" {
+			m.taskManager.SetSortMode(view.sort)
+			m.taskManager.SetSortReverse(view.reverse)
+		}
+		if view.group != "" {
+			m.groupMode = view.group
+		}
+	} else {
+		m.restoreListSettings()
+	}
+
+	m.invalidateCache()
+	if current != nil {
+		m.followTask(current.ID)
+	}
+	m.clampCursor(len(m.listTasks()))
+}
+
+// restoreListSettings returns to the sort order and grouping of the config.
+func (m *Model) restoreListSettings() {
+	mode, err := task.ParseSortMode(m.config.SortMode)
+	if err != nil {
+		mode = task.SortPriority
+	}
+	m.taskManager.SetSortMode(mode)
+	m.taskManager.SetSortReverse(m.config.SortReverse)
+	m.groupMode = configGroupMode(m.config.GroupBy)
+}
+
+// startViewPicker opens the list of views with the cursor on the active one.
+func (m *Model) startViewPicker() {
+	m.promptListMode = m.listMode()
+	m.viewCursor = m.viewIndex + 1
+	m.mode = ModeViewPicker
+}
+
+// viewPickerUpdate handles updates in the view picker. The first entry shows
+// all tasks, the others are the views of the config.
+func (m *Model) viewPickerUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Escape), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.Views):
+			m.mode = m.promptListMode
+		case key.Matches(msg, m.keys.Up):
+			if m.viewCursor > 0 {
+				m.viewCursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.viewCursor < len(m.views) {
+				m.viewCursor++
+			}
+		case key.Matches(msg, m.keys.Enter):
+			m.mode = ModeNormal
+			m.applyView(m.viewCursor - 1)
+		}
+	}
+
+	return m, nil
+}
+
+// viewPickerView renders the list of views.
+func (m *Model) viewPickerView() string {
+	var s strings.Builder
+	title := termenv.String("VIEWS").Bold().Underline()
+	s.WriteString(fmt.Sprintf("%v\n\n", title))
+
+	names := []string{"all tasks"}
+	for _, view := range m.views {
+		names = append(names, view.name)
+	}
+	for i, name := range names {
+		cursor := termenv.String(" ")
+		if i == m.viewCursor {
+			cursor = termenv.String(">").Foreground(termenv.ANSIYellow)
+		}
+		marker := " "
+		if i == m.viewIndex+1 {
+			marker = m.inputStyle.Render("●")
+		}
+		shortcut := "      "
+		if i <= 9 {
+			shortcut = fmt.Sprintf("alt+%d ", i)
+		}
+		line := fmt.Sprintf("%v%s %s%s", cursor, marker, mutedStyle.Render(shortcut), name)
+		if m.width > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+		}
+		s.WriteString(line + "\n")
+	}
+
+	hint := "enter switch · esc close"
+	if len(m.views) == 0 {
+		hint = "no views defined, add them to \"views\" in the config · esc close"
+	}
+	s.WriteString("\n" + mutedStyle.Render(hint) + "\n")
+
+	return s.String()
+}
+
+// viewShortcutIndex returns the index of the view selected by an alt+number
+// key, counting views from alt+1; alt+0 returns -1 for all tasks.
+func viewShortcutIndex(msg tea.KeyMsg) int {
+	digit := msg.String()[len(msg.String())-1]
+	return int(digit-'0') - 1
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/voioo/td/internal/config"
+	"github.com/voioo/td/internal/task"
+)
+
+func TestSavedViews(t *testing.T) {
+	tm := task.NewTaskManager(nil, nil, 0)
+	for _, name := range []string{"deploy", "docs", "alerts"} {
+		tm.AddTask(name)
+	}
+	tm.SetTaskTags(1, []string{"ops"})
+	tm.SetTaskTags(3, []string{"ops"})
+	cfg := config.DefaultConfig()
+	cfg.Views = []config.View{
+		{Name: "ops", Filter: "tag:ops", Sort: "name"},
+		{Name: "broken", Filter: "color:red"},
+		{Name: "high", Filter: "prio:high"},
+	}
+	m, err := NewTestModel(cfg, tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.views) != 2 {
+		t.Fatalf("expected the invalid view to be skipped, got %d views", len(m.views))
+	}
+
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1"), Alt: true})
+	view := m.View()
+	if !strings.Contains(view, "(view: ops)") || strings.Contains(view, "docs") {
+		t.Errorf("expected the ops view, got:\n%s", view)
+	}
+	if len(m.taskCache) != 2 || m.taskCache[0].Name != "alerts" {
+		t.Errorf("expected ops tasks in name order, got %d tasks", len(m.taskCache))
+	}
+	if m.config.SortMode != config.DefaultSortMode || m.configChanged {
+		t.Errorf("expected the order of a view not to be remembered, got %s", m.config.SortMode)
+	}
+
+	// The picker switches back to all tasks in the configured order
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.mode != ModeViewPicker || m.viewCursor != 1 {
+		t.Fatalf("expected the picker on the active view, got mode %d cursor %d", m.mode, m.viewCursor)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal || m.activeView() != nil || len(m.taskCache) != 3 {
+		t.Errorf("expected all tasks again, got %d tasks", len(m.taskCache))
+	}
+	if m.taskManager.SortMode() != task.SortPriority {
+		t.Errorf("expected the configured order to be restored, got %s", m.taskManager.SortMode())
+	}
+
+	// An empty view shows no tasks
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2"), Alt: true})
+	if len(m.taskCache) != 0 || m.cursor != 0 {
+		t.Errorf("expected no high priority tasks, got %d", len(m.taskCache))
+	}
+	m.normalUpdate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0"), Alt: true})
+	if m.activeView() != nil {
+		t.Error("expected alt+0 to show all tasks")
+	}
+}